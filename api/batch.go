@@ -0,0 +1,88 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"sync"
+)
+
+// 检查 API 调用结果：错误码 `codeErr` 表示失败时返回 `codeErr`，HTTP 状态码不是 2xx 时返回错误，否则返回 nil。
+func CheckResponse(resp *Response, codeErr *CodeError) error {
+	if !codeErr.IsSuccess() {
+		return codeErr
+	}
+	if resp != nil && resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// 去除 `values` 中重复及空的字符串，保持原有顺序。
+func DedupeStrings(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+	return result
+}
+
+// 将 `values` 按每块最多 `size` 个拆分，用于批量调用时遵守单次请求的数量上限。
+func ChunkStrings(values []string, size int) [][]string {
+	if size <= 0 || size > len(values) {
+		size = len(values)
+	}
+	if size == 0 {
+		return [][]string{}
+	}
+	chunks := make([][]string, 0, (len(values)+size-1)/size)
+	for start := 0; start < len(values); start += size {
+		end := start + size
+		if end > len(values) {
+			end = len(values)
+		}
+		chunks = append(chunks, values[start:end])
+	}
+	return chunks
+}
+
+// 以不超过 `concurrency` 的并发度执行 `task(0)` 至 `task(n-1)`，全部完成后返回。
+func RunConcurrently(n, concurrency int, task func(i int)) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var (
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, concurrency)
+	)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			task(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultConcurrency   = 4           // 默认的并发请求数
+	defaultRateLimit     = 10          // 默认每个限速周期内允许的请求数
+	defaultRatePer       = time.Second // 默认的限速周期
+	defaultMaxRetries    = 2           // 默认的分块最大重试次数
+	defaultRetryInterval = time.Second // 默认的分块重试间隔
+	maxRegIDsPerChunk    = 1000        // 更新标签、删除设备的别名 API 每次最多支持的 Registration ID 数量
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 批量操作器配置。
+type config struct {
+	logger        jiguang.Logger   // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency   int              // 并发请求数，默认为 4
+	limiter       *api.RateLimiter // API 调用限速器，默认为每秒 10 次
+	chunkSize     int              // 每次请求的 Registration ID 数量，默认为 1000
+	maxRetries    int              // 分块最大重试次数，默认为 2
+	retryInterval time.Duration    // 分块重试间隔，默认为 1 秒
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 批量操作器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置批量操作器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发请求数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行的请求数，默认为 4。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置请求（包括重试）使用的限速器（详见 api.RateLimiter），默认为每秒 10 次。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 分块大小配置选项。
+type chunkSizeOption int
+
+func (o chunkSizeOption) apply(c *config) error {
+	if o <= 0 || o > maxRegIDsPerChunk {
+		return errors.New("`chunkSize` must be in range [1, 1000]")
+	}
+	c.chunkSize = int(o)
+	return nil
+}
+
+// 自定义配置每次请求的 Registration ID 数量，取值范围为 [1, 1000]，默认为 1000。
+func WithChunkSize(n int) ConfigOption {
+	return chunkSizeOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 重试配置选项。
+type retryOption struct {
+	maxRetries int
+	interval   time.Duration
+}
+
+func (o retryOption) apply(c *config) error {
+	if o.maxRetries < 0 {
+		return errors.New("`maxRetries` cannot be negative")
+	}
+	if o.interval < 0 {
+		return errors.New("`interval` cannot be negative")
+	}
+	c.maxRetries = o.maxRetries
+	c.retryInterval = o.interval
+	return nil
+}
+
+// 自定义配置失败分块的最大重试次数 `maxRetries` 和重试间隔 `interval`，默认为间隔 1 秒最多重试 2 次；`maxRetries` 为 0 时不重试。
+//
+// 仅网络错误、HTTP 429 和 5xx 响应会触发重试，其他错误（如鉴权失败）直接记为该分块的失败。
+func WithRetry(maxRetries int, interval time.Duration) ConfigOption {
+	return retryOption{maxRetries, interval}
+}
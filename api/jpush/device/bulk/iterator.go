@@ -0,0 +1,80 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// # Registration ID 迭代器
+//
+// 用于流式提供任意数量的 Registration ID，例如逐行读取人群细分任务导出的文件，而无需一次性加载到内存中。
+type Iterator interface {
+	// 返回下一个 Registration ID；`ok` 为 false 时表示迭代结束，`err` 不为 nil 时表示迭代失败。
+	Next() (regID string, ok bool, err error)
+}
+
+// 基于切片的 Registration ID 迭代器。
+func SliceIterator(registrationIDs []string) Iterator {
+	return &sliceIterator{values: registrationIDs}
+}
+
+type sliceIterator struct {
+	values []string
+	i      int
+}
+
+func (it *sliceIterator) Next() (string, bool, error) {
+	if it.i >= len(it.values) {
+		return "", false, nil
+	}
+	it.i++
+	return it.values[it.i-1], true, nil
+}
+
+// 基于通道的 Registration ID 迭代器，通道关闭时迭代结束。
+func ChanIterator(ch <-chan string) Iterator {
+	return chanIterator(ch)
+}
+
+type chanIterator <-chan string
+
+func (it chanIterator) Next() (string, bool, error) {
+	regID, ok := <-it
+	return regID, ok, nil
+}
+
+// 逐行读取 Registration ID 的迭代器，每行一个，首尾空白会被去除，空行会被忽略。
+func LineIterator(r io.Reader) Iterator {
+	return &lineIterator{scanner: bufio.NewScanner(r)}
+}
+
+type lineIterator struct {
+	scanner *bufio.Scanner
+}
+
+func (it *lineIterator) Next() (string, bool, error) {
+	for it.scanner.Scan() {
+		if line := strings.TrimSpace(it.scanner.Text()); line != "" {
+			return line, true, nil
+		}
+	}
+	if err := it.scanner.Err(); err != nil {
+		return "", false, err
+	}
+	return "", false, nil
+}
@@ -0,0 +1,400 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+)
+
+// 批量操作类型。
+const (
+	OpAddTag      = "add_tag"      // 为标签添加设备
+	OpRemoveTag   = "remove_tag"   // 从标签删除设备
+	OpDeleteAlias = "delete_alias" // 解绑设备与别名
+)
+
+// # 设备操作失败信息
+type Failure struct {
+	RegistrationID string // 设备标识 Registration ID
+	Illegal        bool   // 是否为 API 返回的非法 Registration ID（illegal_rids），此类设备重试无意义
+	Err            error  // 失败原因
+}
+
+// # 批量操作报告
+type Report struct {
+	Operation string    // 操作类型，如 OpAddTag
+	Target    string    // 标签或别名
+	Total     int       // 去重后的 Registration ID 总数
+	Succeeded int       // 操作成功的 Registration ID 数量
+	Requests  int       // 发起的请求次数（包括重试）
+	Retries   int       // 分块重试次数
+	Failures  []Failure // 操作失败的设备，按 Registration ID 排序
+}
+
+// 是否所有设备均操作成功。
+func (rp *Report) IsSuccess() bool {
+	return rp != nil && len(rp.Failures) == 0
+}
+
+// 操作失败且可重试（非 Illegal）的 Registration ID 列表，可直接用于再次执行批量操作。
+func (rp *Report) FailedRegistrationIDs() []string {
+	return rp.registrationIDs(false)
+}
+
+// API 返回的非法 Registration ID 列表。
+func (rp *Report) IllegalRegistrationIDs() []string {
+	return rp.registrationIDs(true)
+}
+
+func (rp *Report) registrationIDs(illegal bool) []string {
+	if rp == nil {
+		return nil
+	}
+	var regIDs []string
+	for _, f := range rp.Failures {
+		if f.Illegal == illegal {
+			regIDs = append(regIDs, f.RegistrationID)
+		}
+	}
+	return regIDs
+}
+
+// # 导出失败设备为 CSV
+//
+// 列依次为：registration_id、illegal、error。
+func (rp *Report) WriteCSV(w io.Writer) error {
+	if rp == nil {
+		return errors.New("nil report")
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"registration_id", "illegal", "error"}); err != nil {
+		return err
+	}
+	for _, f := range rp.Failures {
+		msg := ""
+		if f.Err != nil {
+			msg = f.Err.Error()
+		}
+		if err := cw.Write([]string{f.RegistrationID, strconv.FormatBool(f.Illegal), msg}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 标签与别名批量操作器
+//
+// 突破「更新标签」与「删除设备的别名」接口单次最多 1000 个 Registration ID 的限制：将任意数量的 Registration ID（切片或 Iterator）
+// 按分块大小切分，在限速范围内并发请求；API 返回的非法 Registration ID 会被记录并从分块中剔除后重新提交，
+// 网络错误、HTTP 429 和 5xx 响应的分块会按配置重试，最终将所有设备的失败信息汇总到一份 Report 中。
+//
+// 为了去重，操作期间会在内存中保存已读取的 Registration ID。
+type Operator struct {
+	device device.APIv3
+	cfg    config
+}
+
+// 创建新的标签与别名批量操作器。
+//   - deviceAPI：【必填】设备 API v3 接口；
+//   - opts：【可选】批量操作器配置选项。
+func NewOperator(deviceAPI device.APIv3, opts ...ConfigOption) (*Operator, error) {
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+
+	c := config{
+		logger:        api.DefaultJPushLogger,
+		concurrency:   defaultConcurrency,
+		chunkSize:     maxRegIDsPerChunk,
+		maxRetries:    defaultMaxRetries,
+		retryInterval: defaultRetryInterval,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Operator{device: deviceAPI, cfg: c}, nil
+}
+
+// 为标签 `tag` 批量添加设备（重复及空的 Registration ID 会被忽略）。
+func (o *Operator) AddTag(ctx context.Context, tag string, registrationIDs []string) (*Report, error) {
+	return o.AddTagFrom(ctx, tag, SliceIterator(registrationIDs))
+}
+
+// 为标签 `tag` 批量添加迭代器 `it` 提供的设备。
+//
+// 迭代失败时会停止读取，已读取的设备仍会处理完毕，并同时返回报告和迭代错误。
+func (o *Operator) AddTagFrom(ctx context.Context, tag string, it Iterator) (*Report, error) {
+	if tag == "" {
+		return nil, errors.New("`tag` cannot be empty")
+	}
+	return o.run(ctx, OpAddTag, tag, it)
+}
+
+// 从标签 `tag` 批量删除设备（重复及空的 Registration ID 会被忽略）。
+func (o *Operator) RemoveTag(ctx context.Context, tag string, registrationIDs []string) (*Report, error) {
+	return o.RemoveTagFrom(ctx, tag, SliceIterator(registrationIDs))
+}
+
+// 从标签 `tag` 批量删除迭代器 `it` 提供的设备，迭代失败时的处理同 AddTagFrom。
+func (o *Operator) RemoveTagFrom(ctx context.Context, tag string, it Iterator) (*Report, error) {
+	if tag == "" {
+		return nil, errors.New("`tag` cannot be empty")
+	}
+	return o.run(ctx, OpRemoveTag, tag, it)
+}
+
+// 批量解绑设备与别名 `alias` 的关系（重复及空的 Registration ID 会被忽略）。
+func (o *Operator) DeleteAliases(ctx context.Context, alias string, registrationIDs []string) (*Report, error) {
+	return o.DeleteAliasesFrom(ctx, alias, SliceIterator(registrationIDs))
+}
+
+// 批量解绑迭代器 `it` 提供的设备与别名 `alias` 的关系，迭代失败时的处理同 AddTagFrom。
+func (o *Operator) DeleteAliasesFrom(ctx context.Context, alias string, it Iterator) (*Report, error) {
+	if alias == "" {
+		return nil, errors.New("`alias` cannot be empty")
+	}
+	return o.run(ctx, OpDeleteAlias, alias, it)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 单个分块的处理结果。
+type chunkOutcome struct {
+	succeeded int
+	requests  int
+	retries   int
+	failures  []Failure
+}
+
+func (o *Operator) run(ctx context.Context, op, target string, it Iterator) (*Report, error) {
+	if it == nil {
+		return nil, errors.New("`it` cannot be nil")
+	}
+
+	report := &Report{Operation: op, Target: target}
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, o.cfg.concurrency)
+	)
+	dispatch := func(regIDs []string) {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer func() { <-sem; wg.Done() }()
+			out := o.process(ctx, op, target, regIDs)
+			mu.Lock()
+			report.Succeeded += out.succeeded
+			report.Requests += out.requests
+			report.Retries += out.retries
+			report.Failures = append(report.Failures, out.failures...)
+			mu.Unlock()
+		}()
+	}
+
+	var (
+		iterErr error
+		seen    = make(map[string]struct{})
+		buf     = make([]string, 0, o.cfg.chunkSize)
+	)
+	for {
+		if iterErr = ctx.Err(); iterErr != nil {
+			break
+		}
+		regID, ok, err := it.Next()
+		if err != nil {
+			iterErr = err
+			break
+		}
+		if !ok {
+			break
+		}
+		if regID == "" {
+			continue
+		}
+		if _, dup := seen[regID]; dup {
+			continue
+		}
+		seen[regID] = struct{}{}
+		report.Total++
+		if buf = append(buf, regID); len(buf) == o.cfg.chunkSize {
+			dispatch(buf)
+			buf = make([]string, 0, o.cfg.chunkSize)
+		}
+	}
+	if len(buf) > 0 {
+		dispatch(buf)
+	}
+	wg.Wait()
+
+	sort.Slice(report.Failures, func(i, j int) bool {
+		return report.Failures[i].RegistrationID < report.Failures[j].RegistrationID
+	})
+	if iterErr != nil {
+		o.cfg.logger.Errorf(ctx, "批量操作 %s %s 读取 Registration ID 中断：%s", op, target, iterErr)
+	}
+	o.cfg.logger.Infof(ctx, "批量操作 %s %s 完成：共 %d 个设备，成功 %d 个，失败 %d 个，请求 %d 次，重试 %d 次",
+		op, target, report.Total, report.Succeeded, len(report.Failures), report.Requests, report.Retries)
+	return report, iterErr
+}
+
+// 处理单个分块：剔除非法 Registration ID 后重新提交，可重试的错误按配置重试。
+func (o *Operator) process(ctx context.Context, op, target string, regIDs []string) chunkOutcome {
+	var out chunkOutcome
+	pending := regIDs
+	for {
+		out.requests++
+		illegal, retryable, err := o.call(ctx, op, target, pending)
+		if err == nil {
+			out.succeeded += len(pending)
+			return out
+		}
+
+		if rejected, rest := split(pending, illegal); len(rejected) > 0 {
+			for _, regID := range rejected {
+				out.failures = append(out.failures, Failure{RegistrationID: regID, Illegal: true, Err: err})
+			}
+			o.cfg.logger.Warnf(ctx, "批量操作 %s %s 有 %d 个非法的 Registration ID 被拒绝", op, target, len(rejected))
+			if pending = rest; len(pending) == 0 {
+				return out
+			}
+			continue
+		}
+
+		if !retryable || out.retries >= o.cfg.maxRetries || !o.sleep(ctx) {
+			o.cfg.logger.Errorf(ctx, "批量操作 %s %s 有 %d 个设备失败：%s", op, target, len(pending), err)
+			for _, regID := range pending {
+				out.failures = append(out.failures, Failure{RegistrationID: regID, Err: err})
+			}
+			return out
+		}
+		out.retries++
+	}
+}
+
+// 发起一次请求，返回 API 指出的非法 Registration ID、是否可重试以及失败原因。
+func (o *Operator) call(ctx context.Context, op, target string, regIDs []string) ([]string, bool, error) {
+	if err := o.cfg.limiter.Wait(ctx); err != nil {
+		return nil, false, err
+	}
+
+	var (
+		resp    *api.Response
+		codeErr error
+		illegal []string
+	)
+	switch op {
+	case OpAddTag, OpRemoveTag:
+		var adds, removes []string
+		if op == OpAddTag {
+			adds = regIDs
+		} else {
+			removes = regIDs
+		}
+		result, err := o.device.SetTag(ctx, target, adds, removes)
+		if err != nil {
+			return nil, ctx.Err() == nil, err
+		}
+		o.cfg.limiter.Observe(result.Rate)
+		if result.IsSuccess() {
+			return nil, false, nil
+		}
+		resp = result.Response
+		if e := result.Error; e != nil && !e.IsSuccess() {
+			codeErr, illegal = e, e.IllegalRIDs
+		}
+	case OpDeleteAlias:
+		result, err := o.device.DeleteAliases(ctx, target, regIDs)
+		if err != nil {
+			return nil, ctx.Err() == nil, err
+		}
+		o.cfg.limiter.Observe(result.Rate)
+		if result.IsSuccess() {
+			return nil, false, nil
+		}
+		resp = result.Response
+		if e := result.Error; e != nil && !e.IsSuccess() {
+			codeErr, illegal = e, e.IllegalRIDs
+		}
+	default:
+		return nil, false, fmt.Errorf("unsupported operation %q", op)
+	}
+
+	if codeErr == nil {
+		codeErr = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5
+	return illegal, retryable, codeErr
+}
+
+// 等待重试间隔，`ctx` 结束时返回 false。
+func (o *Operator) sleep(ctx context.Context) bool {
+	if o.cfg.retryInterval <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(o.cfg.retryInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// 将 `regIDs` 拆分为属于 `illegal` 的部分和其余部分。
+func split(regIDs, illegal []string) (rejected, rest []string) {
+	if len(illegal) == 0 {
+		return nil, regIDs
+	}
+	set := make(map[string]struct{}, len(illegal))
+	for _, regID := range illegal {
+		set[regID] = struct{}{}
+	}
+	rest = make([]string, 0, len(regIDs))
+	for _, regID := range regIDs {
+		if _, ok := set[regID]; ok {
+			rejected = append(rejected, regID)
+		} else {
+			rest = append(rest, regID)
+		}
+	}
+	return rejected, rest
+}
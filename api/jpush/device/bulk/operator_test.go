@@ -0,0 +1,255 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+)
+
+// 模拟一次请求的响应：HTTP 状态码、非法 Registration ID 以及网络错误。
+type response struct {
+	status  int
+	illegal []string
+	err     error
+}
+
+type fakeDevice struct {
+	device.APIv3
+	mu        sync.Mutex
+	calls     [][]string
+	responses []response // 按调用顺序返回，用尽后返回成功
+}
+
+func (f *fakeDevice) next(regIDs []string) response {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, append([]string(nil), regIDs...))
+	if len(f.responses) == 0 {
+		return response{status: 200}
+	}
+	r := f.responses[0]
+	f.responses = f.responses[1:]
+	return r
+}
+
+func (f *fakeDevice) SetTag(_ context.Context, _ string, adds, removes []string) (*device.TagSetResult, error) {
+	r := f.next(append(append([]string(nil), adds...), removes...))
+	if r.err != nil {
+		return nil, r.err
+	}
+	result := &device.TagSetResult{Response: &api.Response{StatusCode: r.status}}
+	if r.status/100 != 2 {
+		result.Error = &device.TagSetError{CodeError: api.CodeError{Code: 1011, Message: "failed"}, IllegalRIDs: r.illegal}
+	}
+	return result, nil
+}
+
+func (f *fakeDevice) DeleteAliases(_ context.Context, _ string, regIDs []string) (*device.AliasesDeleteResult, error) {
+	r := f.next(regIDs)
+	if r.err != nil {
+		return nil, r.err
+	}
+	result := &device.AliasesDeleteResult{Response: &api.Response{StatusCode: r.status}}
+	if r.status/100 != 2 {
+		result.Error = &device.AliasesDeleteError{CodeError: api.CodeError{Code: 1011, Message: "failed"}, IllegalRIDs: r.illegal}
+	}
+	return result, nil
+}
+
+func newTestOperator(t *testing.T, f *fakeDevice, opts ...ConfigOption) *Operator {
+	t.Helper()
+	limiter, err := api.NewRateLimiter(1000, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts = append([]ConfigOption{WithRateLimiter(limiter), WithConcurrency(1), WithRetry(2, 0)}, opts...)
+	o, err := NewOperator(f, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return o
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		regIDs   []string
+		illegal  []string
+		rejected []string
+		rest     []string
+	}{
+		{"no illegal", []string{"a", "b"}, nil, nil, []string{"a", "b"}},
+		{"some illegal", []string{"a", "b", "c", "d"}, []string{"d", "b"}, []string{"b", "d"}, []string{"a", "c"}},
+		{"all illegal", []string{"a", "b"}, []string{"a", "b"}, []string{"a", "b"}, []string{}},
+		{"unknown illegal", []string{"a", "b"}, []string{"x"}, nil, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejected, rest := split(tt.regIDs, tt.illegal)
+			if !reflect.DeepEqual(rejected, tt.rejected) {
+				t.Errorf("rejected = %v, want %v", rejected, tt.rejected)
+			}
+			if !reflect.DeepEqual(rest, tt.rest) {
+				t.Errorf("rest = %v, want %v", rest, tt.rest)
+			}
+		})
+	}
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		responses []response
+		requests  int
+		retries   int
+		succeeded int
+	}{
+		{"429 exhausted", []response{{status: 429}, {status: 429}, {status: 429}}, 3, 2, 0},
+		{"500 exhausted", []response{{status: 500}, {status: 502}, {status: 503}}, 3, 2, 0},
+		{"network error exhausted", []response{{err: errors.New("reset")}, {err: errors.New("reset")}, {err: errors.New("reset")}}, 3, 2, 0},
+		{"429 then success", []response{{status: 429}}, 2, 1, 3},
+		{"503 then success", []response{{status: 503}, {status: 503}}, 3, 2, 3},
+		{"400 not retried", []response{{status: 400}}, 1, 0, 0},
+		{"401 not retried", []response{{status: 401}}, 1, 0, 0},
+		{"404 not retried", []response{{status: 404}}, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeDevice{responses: tt.responses}
+			o := newTestOperator(t, f)
+			report, err := o.AddTag(context.Background(), "vip", []string{"a", "b", "c"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if report.Requests != tt.requests || report.Retries != tt.retries || report.Succeeded != tt.succeeded {
+				t.Fatalf("requests = %d, retries = %d, succeeded = %d, want %d, %d, %d",
+					report.Requests, report.Retries, report.Succeeded, tt.requests, tt.retries, tt.succeeded)
+			}
+			if len(f.calls) != tt.requests {
+				t.Fatalf("calls = %d, want %d", len(f.calls), tt.requests)
+			}
+			if failed := report.FailedRegistrationIDs(); len(failed) != 3-tt.succeeded {
+				t.Fatalf("FailedRegistrationIDs = %v", failed)
+			}
+			if illegal := report.IllegalRegistrationIDs(); len(illegal) != 0 {
+				t.Fatalf("IllegalRegistrationIDs = %v, want none", illegal)
+			}
+		})
+	}
+}
+
+func TestIllegalResubmit(t *testing.T) {
+	tests := []struct {
+		name      string
+		op        string
+		responses []response
+		calls     [][]string
+		succeeded int
+		retries   int
+		illegal   []string
+		failed    []string
+	}{
+		{
+			name:      "add tag",
+			op:        OpAddTag,
+			responses: []response{{status: 400, illegal: []string{"d", "b"}}},
+			calls:     [][]string{{"a", "b", "c", "d"}, {"a", "c"}},
+			succeeded: 2,
+			illegal:   []string{"b", "d"},
+		},
+		{
+			name:      "delete aliases",
+			op:        OpDeleteAlias,
+			responses: []response{{status: 400, illegal: []string{"a"}}},
+			calls:     [][]string{{"a", "b", "c", "d"}, {"b", "c", "d"}},
+			succeeded: 3,
+			illegal:   []string{"a"},
+		},
+		{
+			name:      "all illegal",
+			op:        OpRemoveTag,
+			responses: []response{{status: 400, illegal: []string{"a", "b", "c", "d"}}},
+			calls:     [][]string{{"a", "b", "c", "d"}},
+			illegal:   []string{"a", "b", "c", "d"},
+		},
+		{
+			name:      "resubmit then retry",
+			op:        OpAddTag,
+			responses: []response{{status: 400, illegal: []string{"c"}}, {status: 503}},
+			calls:     [][]string{{"a", "b", "c", "d"}, {"a", "b", "d"}, {"a", "b", "d"}},
+			succeeded: 3,
+			retries:   1,
+			illegal:   []string{"c"},
+		},
+		{
+			name:      "resubmit then fail",
+			op:        OpAddTag,
+			responses: []response{{status: 400, illegal: []string{"c"}}, {status: 403}},
+			calls:     [][]string{{"a", "b", "c", "d"}, {"a", "b", "d"}},
+			illegal:   []string{"c"},
+			failed:    []string{"a", "b", "d"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := &fakeDevice{responses: tt.responses}
+			o := newTestOperator(t, f)
+			regIDs := []string{"a", "b", "c", "d"}
+
+			var (
+				report *Report
+				err    error
+			)
+			switch tt.op {
+			case OpAddTag:
+				report, err = o.AddTag(ctx, "vip", regIDs)
+			case OpRemoveTag:
+				report, err = o.RemoveTag(ctx, "vip", regIDs)
+			case OpDeleteAlias:
+				report, err = o.DeleteAliases(ctx, "alias", regIDs)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(f.calls, tt.calls) {
+				t.Errorf("calls = %v, want %v", f.calls, tt.calls)
+			}
+			if report.Succeeded != tt.succeeded {
+				t.Errorf("Succeeded = %d, want %d", report.Succeeded, tt.succeeded)
+			}
+			if got := report.IllegalRegistrationIDs(); !reflect.DeepEqual(got, tt.illegal) {
+				t.Errorf("IllegalRegistrationIDs = %v, want %v", got, tt.illegal)
+			}
+			if got := report.FailedRegistrationIDs(); !reflect.DeepEqual(got, tt.failed) {
+				t.Errorf("FailedRegistrationIDs = %v, want %v", got, tt.failed)
+			}
+			if report.Retries != tt.retries {
+				t.Errorf("Retries = %d, want %d", report.Retries, tt.retries)
+			}
+		})
+	}
+}
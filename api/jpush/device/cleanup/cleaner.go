@@ -0,0 +1,420 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+)
+
+var (
+	ErrNoTargets           = errors.New("no registration id or alias to scan")       // 没有需要扫描的 Registration ID 或别名
+	ErrSafetyLimitExceeded = errors.New("inactive devices exceed the safety limits") // 不活跃设备数超出安全限制，已放弃清理
+)
+
+// 接口对超过该时长未在线的设备不返回最后在线时间。
+const unknownAfter = 48 * time.Hour
+
+// 设备的判定结果。
+type Verdict string
+
+const (
+	VerdictActive   Verdict = "active"   // 活跃（在线或最后在线时间未超过阈值）
+	VerdictInactive Verdict = "inactive" // 不活跃，将被清理
+	VerdictUnknown  Verdict = "unknown"  // 最后在线时间未知（两天之前），无法确认是否超过阈值，不清理
+	VerdictInvalid  Verdict = "invalid"  // 无效或不属于当前应用的 Registration ID，接口未返回在线状态，不清理
+	VerdictError    Verdict = "error"    // 查询在线状态失败，不清理
+)
+
+// # 单个设备的扫描与清理结果
+type DeviceReport struct {
+	RegistrationID string    // 设备标识 Registration ID
+	Alias          string    // 通过别名扫描时为设备所属的别名
+	Online         bool      // 10 分钟之内是否在线
+	LastOnline     time.Time // 最后一次在线时间，在线或未知时为零值
+	Verdict        Verdict   // 判定结果
+	Cleared        bool      // 是否已清理
+	Err            error     // 查询或清理失败的原因
+}
+
+// # 清理报告
+type Report struct {
+	DryRun    bool           // 是否为试运行
+	Threshold time.Duration  // 不活跃阈值
+	Cutoff    time.Time      // 最后在线时间早于该时间的设备视为不活跃
+	Devices   []DeviceReport // 各设备的结果，顺序与扫描的顺序一致
+	Aborted   bool           // 是否因超出安全限制而放弃清理
+}
+
+// 各判定结果的设备数。
+func (rp *Report) Counts() map[Verdict]int {
+	counts := make(map[Verdict]int)
+	if rp != nil {
+		for _, d := range rp.Devices {
+			counts[d.Verdict]++
+		}
+	}
+	return counts
+}
+
+// 判定为不活跃的设备。
+func (rp *Report) Inactive() []DeviceReport {
+	if rp == nil {
+		return nil
+	}
+	var inactive []DeviceReport
+	for _, d := range rp.Devices {
+		if d.Verdict == VerdictInactive {
+			inactive = append(inactive, d)
+		}
+	}
+	return inactive
+}
+
+// 已清理的设备数。
+func (rp *Report) Cleared() int {
+	n := 0
+	if rp != nil {
+		for _, d := range rp.Devices {
+			if d.Cleared {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// 查询或清理失败的设备。
+func (rp *Report) Failed() []DeviceReport {
+	if rp == nil {
+		return nil
+	}
+	var failed []DeviceReport
+	for _, d := range rp.Devices {
+		if d.Err != nil {
+			failed = append(failed, d)
+		}
+	}
+	return failed
+}
+
+func (rp *Report) String() string {
+	if rp == nil {
+		return ""
+	}
+	c := rp.Counts()
+	return fmt.Sprintf("Cleanup (threshold %s, dry run: %t, aborted: %t): %d scanned, %d active, %d inactive, %d unknown, %d invalid, %d errors, %d cleared.",
+		rp.Threshold, rp.DryRun, rp.Aborted, len(rp.Devices), c[VerdictActive], c[VerdictInactive], c[VerdictUnknown], c[VerdictInvalid], c[VerdictError], rp.Cleared())
+}
+
+// # 导出为 CSV
+//
+// 列依次为：registration_id、alias、online、last_online_time、verdict、cleared、error。
+func (rp *Report) WriteCSV(w io.Writer) error {
+	if rp == nil {
+		return errors.New("nil report")
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"registration_id", "alias", "online", "last_online_time", "verdict", "cleared", "error"}); err != nil {
+		return err
+	}
+	for _, d := range rp.Devices {
+		var lastOnline, msg string
+		if !d.LastOnline.IsZero() {
+			lastOnline = d.LastOnline.Format(time.RFC3339)
+		}
+		if d.Err != nil {
+			msg = d.Err.Error()
+		}
+		record := []string{d.RegistrationID, d.Alias, strconv.FormatBool(d.Online), lastOnline, string(d.Verdict), strconv.FormatBool(d.Cleared), msg}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 不活跃设备清理器
+//
+// 通过「获取用户在线状态」接口（VIP）按 1000 个一组查询设备的在线状态，找出超过阈值未在线的设备，
+// 并通过 ClearDeviceAll（或 WithClear 指定的 ClearDevice* 方法）清空其标签、别名与手机号码，避免失效的绑定虚增推送目标数、拉低送达率。
+//
+// 注意：设备 API 没有列出标签下所有设备的接口，需要按标签清理时，请先获取候选设备的 Registration ID 后调用 Scan。
+type Cleaner struct {
+	device    device.APIv3
+	threshold time.Duration
+	cfg       config
+}
+
+// 创建新的不活跃设备清理器。
+//   - deviceAPI：【必填】设备 API v3 接口；
+//   - threshold：【必填】不活跃阈值，最后在线时间早于当前时间减去该阈值的设备视为不活跃；
+//   - opts：【可选】清理器配置选项。
+func NewCleaner(deviceAPI device.APIv3, threshold time.Duration, opts ...ConfigOption) (*Cleaner, error) {
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+	if threshold <= 0 {
+		return nil, errors.New("`threshold` must be positive")
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		concurrency: defaultConcurrency,
+		clearTags:   true,
+		clearAlias:  true,
+		clearMobile: true,
+		maxClear:    defaultMaxClear,
+		maxRatio:    defaultMaxRatio,
+		platforms:   defaultPlatforms,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Cleaner{device: deviceAPI, threshold: threshold, cfg: c}, nil
+}
+
+// 扫描指定的设备并清理其中不活跃的设备（重复及空的 Registration ID 会被忽略）。
+//
+// 超出安全限制时不清理任何设备，返回报告（Aborted 为 true）和 ErrSafetyLimitExceeded。
+func (c *Cleaner) Scan(ctx context.Context, registrationIDs []string) (*Report, error) {
+	registrationIDs = api.DedupeStrings(registrationIDs)
+	if len(registrationIDs) == 0 {
+		return nil, ErrNoTargets
+	}
+	devices := make([]DeviceReport, len(registrationIDs))
+	for i, regID := range registrationIDs {
+		devices[i].RegistrationID = regID
+	}
+	return c.scan(ctx, devices)
+}
+
+// 通过 GetAlias 按平台解析别名绑定的设备后扫描并清理，其余同 Scan；解析失败的别名会中止扫描。
+func (c *Cleaner) ScanAliases(ctx context.Context, aliases []string) (*Report, error) {
+	aliases = api.DedupeStrings(aliases)
+	if len(aliases) == 0 {
+		return nil, ErrNoTargets
+	}
+
+	resolved := make([][]string, len(aliases))
+	errs := make([]error, len(aliases))
+	api.RunConcurrently(len(aliases), c.cfg.concurrency, func(i int) {
+		resolved[i], errs[i] = c.resolveAlias(ctx, aliases[i])
+	})
+
+	var (
+		devices []DeviceReport
+		seen    = make(map[string]struct{})
+	)
+	for i, alias := range aliases {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("resolve alias %q: %w", alias, errs[i])
+		}
+		for _, regID := range resolved[i] {
+			if _, ok := seen[regID]; !ok {
+				seen[regID] = struct{}{}
+				devices = append(devices, DeviceReport{RegistrationID: regID, Alias: alias})
+			}
+		}
+	}
+	if len(devices) == 0 {
+		return nil, ErrNoTargets
+	}
+	return c.scan(ctx, devices)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (c *Cleaner) scan(ctx context.Context, devices []DeviceReport) (*Report, error) {
+	now := time.Now()
+	report := &Report{DryRun: c.cfg.dryRun, Threshold: c.threshold, Cutoff: now.Add(-c.threshold), Devices: devices}
+
+	// 查询在线状态
+	var chunks [][]int
+	for start := 0; start < len(devices); start += maxRegIDsPerChunk {
+		end := start + maxRegIDsPerChunk
+		if end > len(devices) {
+			end = len(devices)
+		}
+		idx := make([]int, 0, end-start)
+		for i := start; i < end; i++ {
+			idx = append(idx, i)
+		}
+		chunks = append(chunks, idx)
+	}
+	api.RunConcurrently(len(chunks), c.cfg.concurrency, func(k int) {
+		regIDs := make([]string, len(chunks[k]))
+		for j, i := range chunks[k] {
+			regIDs[j] = devices[i].RegistrationID
+		}
+		statuses, err := c.status(ctx, regIDs)
+		for _, i := range chunks[k] {
+			d := &devices[i]
+			if err != nil {
+				d.Verdict, d.Err = VerdictError, err
+				continue
+			}
+			s, ok := statuses[d.RegistrationID]
+			if !ok {
+				d.Verdict = VerdictInvalid
+				continue
+			}
+			d.Online = s.Online
+			if s.LastOnlineTime != nil {
+				d.LastOnline = s.LastOnlineTime.Time
+			}
+			d.Verdict = c.judge(d.Online, d.LastOnline, report.Cutoff)
+		}
+	})
+
+	// 安全限制
+	counts := report.Counts()
+	inactive := counts[VerdictInactive]
+	valid := len(devices) - counts[VerdictInvalid] - counts[VerdictError]
+	if c.cfg.maxClear > 0 && inactive > c.cfg.maxClear {
+		report.Aborted = true
+	}
+	if c.cfg.maxRatio > 0 && valid > 0 && float64(inactive)/float64(valid) > c.cfg.maxRatio {
+		report.Aborted = true
+	}
+	if report.Aborted {
+		c.cfg.logger.Errorf(ctx, "不活跃设备 %d 个（有效设备共 %d 个），超过安全限制（最多 %d 个，比例 %.2f），不清理任何设备",
+			inactive, valid, c.cfg.maxClear, c.cfg.maxRatio)
+		return report, ErrSafetyLimitExceeded
+	}
+
+	// 清理
+	if !c.cfg.dryRun {
+		var targets []int
+		for i := range devices {
+			if devices[i].Verdict == VerdictInactive {
+				targets = append(targets, i)
+			}
+		}
+		api.RunConcurrently(len(targets), c.cfg.concurrency, func(k int) {
+			d := &devices[targets[k]]
+			if d.Err = c.clear(ctx, d.RegistrationID); d.Err != nil {
+				c.cfg.logger.Errorf(ctx, "清理设备 %s 失败：%s", d.RegistrationID, d.Err)
+			} else {
+				d.Cleared = true
+			}
+		})
+	}
+	c.cfg.logger.Infof(ctx, "不活跃设备清理完成：%s", report)
+	return report, nil
+}
+
+func (c *Cleaner) judge(online bool, lastOnline, cutoff time.Time) Verdict {
+	switch {
+	case online:
+		return VerdictActive
+	case !lastOnline.IsZero():
+		if lastOnline.Before(cutoff) {
+			return VerdictInactive
+		}
+		return VerdictActive
+	case c.threshold <= unknownAfter || c.cfg.unknownAsIdle:
+		return VerdictInactive
+	default:
+		return VerdictUnknown
+	}
+}
+
+func (c *Cleaner) status(ctx context.Context, regIDs []string) (map[string]device.DeviceStatusResult, error) {
+	if err := c.cfg.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	result, err := c.device.GetDeviceStatus(ctx, regIDs)
+	if err != nil {
+		return nil, err
+	}
+	c.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	return result.Result, nil
+}
+
+func (c *Cleaner) resolveAlias(ctx context.Context, alias string) ([]string, error) {
+	var regIDs []string
+	for _, p := range c.cfg.platforms {
+		if err := c.cfg.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		result, err := c.device.GetAlias(ctx, alias, p)
+		if err != nil {
+			return nil, err
+		}
+		c.cfg.limiter.Observe(result.Rate)
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, err
+		}
+		for _, d := range result.Data {
+			regIDs = append(regIDs, d.RegistrationID)
+		}
+	}
+	return regIDs, nil
+}
+
+func (c *Cleaner) clear(ctx context.Context, regID string) error {
+	if err := c.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	var clearFunc func(context.Context, string) (*device.DeviceClearResult, error)
+	tags, alias, mobile := c.cfg.clearTags, c.cfg.clearAlias, c.cfg.clearMobile
+	switch {
+	case tags && alias && mobile:
+		clearFunc = c.device.ClearDeviceAll
+	case tags && alias:
+		clearFunc = c.device.ClearDeviceTagsAndAlias
+	case tags && mobile:
+		clearFunc = c.device.ClearDeviceTagsAndMobile
+	case alias && mobile:
+		clearFunc = c.device.ClearDeviceAliasAndMobile
+	case tags:
+		clearFunc = c.device.ClearDeviceTags
+	case alias:
+		clearFunc = c.device.ClearDeviceAlias
+	default:
+		clearFunc = c.device.ClearDeviceMobile
+	}
+	result, err := clearFunc(ctx, regID)
+	if err != nil {
+		return err
+	}
+	c.cfg.limiter.Observe(result.Rate)
+	return api.CheckResponse(result.Response, result.Error)
+}
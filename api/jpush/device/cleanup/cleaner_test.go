@@ -0,0 +1,147 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 内存中的设备 API，只实现清理器用到的接口。
+type fakeDevice struct {
+	device.APIv3
+
+	mu      sync.Mutex
+	status  map[string]device.DeviceStatusResult // 未包含的 Registration ID 视为无效
+	cleared map[string]bool
+}
+
+func ok() *api.Response { return &api.Response{StatusCode: 200} }
+
+func (f *fakeDevice) GetDeviceStatus(_ context.Context, regIDs []string) (*device.DeviceStatusGetResult, error) {
+	result := &device.DeviceStatusGetResult{Response: ok(), Result: map[string]device.DeviceStatusResult{}}
+	for _, regID := range regIDs {
+		if s, ok := f.status[regID]; ok {
+			result.Result[regID] = s
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeDevice) ClearDeviceAll(_ context.Context, regID string) (*device.DeviceClearResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cleared[regID] = true
+	return &device.DeviceClearResult{Response: ok()}, nil
+}
+
+func TestJudge(t *testing.T) {
+	now := time.Now()
+	cutoff := now.Add(-7 * 24 * time.Hour)
+	tests := []struct {
+		name          string
+		threshold     time.Duration
+		unknownAsIdle bool
+		online        bool
+		lastOnline    time.Time
+		want          Verdict
+	}{
+		{name: "online", threshold: 7 * 24 * time.Hour, online: true, lastOnline: now.Add(-30 * 24 * time.Hour), want: VerdictActive},
+		{name: "recently online", threshold: 7 * 24 * time.Hour, lastOnline: now.Add(-time.Hour), want: VerdictActive},
+		{name: "before cutoff", threshold: 7 * 24 * time.Hour, lastOnline: cutoff.Add(-time.Second), want: VerdictInactive},
+		{name: "at cutoff", threshold: 7 * 24 * time.Hour, lastOnline: cutoff, want: VerdictActive},
+		{name: "unknown with long threshold", threshold: 7 * 24 * time.Hour, want: VerdictUnknown},
+		{name: "unknown as inactive", threshold: 7 * 24 * time.Hour, unknownAsIdle: true, want: VerdictInactive},
+		{name: "unknown with short threshold", threshold: unknownAfter, want: VerdictInactive},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Cleaner{threshold: tt.threshold, cfg: config{unknownAsIdle: tt.unknownAsIdle}}
+			if got := c.judge(tt.online, tt.lastOnline, cutoff); got != tt.want {
+				t.Errorf("judge() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScanSafetyLimits(t *testing.T) {
+	tests := []struct {
+		name                      string
+		inactive, active, invalid int
+		maxClear                  int
+		maxRatio                  float64
+		wantAborted               bool
+	}{
+		{name: "within limits", inactive: 3, active: 7, maxClear: 5, maxRatio: 0.5},
+		{name: "ratio at limit", inactive: 5, active: 5, maxRatio: 0.5},
+		{name: "ratio exceeded", inactive: 6, active: 4, maxRatio: 0.5, wantAborted: true},
+		{name: "count at limit", inactive: 3, active: 0, maxClear: 3},
+		{name: "count exceeded", inactive: 3, active: 7, maxClear: 2, wantAborted: true},
+		{name: "invalid devices excluded from ratio", inactive: 3, active: 2, invalid: 5, maxRatio: 0.5, wantAborted: true},
+		{name: "limits disabled", inactive: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeDevice{status: map[string]device.DeviceStatusResult{}, cleared: map[string]bool{}}
+			var regIDs []string
+			add := func(n int, prefix string, s *device.DeviceStatusResult) {
+				for i := 0; i < n; i++ {
+					regID := fmt.Sprintf("%s%d", prefix, i)
+					regIDs = append(regIDs, regID)
+					if s != nil {
+						f.status[regID] = *s
+					}
+				}
+			}
+			last := jiguang.NewLocalDateTime(time.Now().Add(-30*24*time.Hour), nil)
+			add(tt.inactive, "inactive", &device.DeviceStatusResult{LastOnlineTime: &last})
+			add(tt.active, "active", &device.DeviceStatusResult{Online: true})
+			add(tt.invalid, "invalid", nil)
+
+			c, err := NewCleaner(f, 7*24*time.Hour, WithSafetyLimits(tt.maxClear, tt.maxRatio))
+			if err != nil {
+				t.Fatalf("new cleaner: %v", err)
+			}
+			report, err := c.Scan(context.Background(), regIDs)
+			if tt.wantAborted {
+				if !errors.Is(err, ErrSafetyLimitExceeded) || !report.Aborted {
+					t.Fatalf("scan err = %v, aborted = %v, want %v", err, report.Aborted, ErrSafetyLimitExceeded)
+				}
+				if len(f.cleared) != 0 {
+					t.Errorf("cleared %d devices after abort", len(f.cleared))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scan: %v", err)
+			}
+			counts := report.Counts()
+			if counts[VerdictInactive] != tt.inactive || counts[VerdictActive] != tt.active || counts[VerdictInvalid] != tt.invalid {
+				t.Errorf("counts = %v", counts)
+			}
+			if len(f.cleared) != tt.inactive || report.Cleared() != tt.inactive {
+				t.Errorf("cleared %d devices (report %d), want %d", len(f.cleared), report.Cleared(), tt.inactive)
+			}
+		})
+	}
+}
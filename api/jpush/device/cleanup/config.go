@@ -0,0 +1,221 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cleanup
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultConcurrency = 4           // 默认的并发请求数
+	defaultRateLimit   = 10          // 默认每个限速周期内允许的请求数
+	defaultRatePer     = time.Second // 默认的限速周期
+	defaultMaxClear    = 1000        // 默认单次最多清理的设备数
+	defaultMaxRatio    = 0.5         // 默认单次最多清理的设备比例
+	maxRegIDsPerChunk  = 1000        // 获取用户在线状态 API 每次最多查询的 Registration ID 数量
+)
+
+// 默认解析别名时查询的平台。
+var defaultPlatforms = []platform.Platform{platform.Android, platform.IOS, platform.HMOS, platform.QuickApp}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 不活跃设备清理器配置。
+type config struct {
+	logger        jiguang.Logger      // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency   int                 // 并发请求数，默认为 4
+	limiter       *api.RateLimiter    // API 调用限速器，默认为每秒 10 次
+	clearTags     bool                // 是否清空标签，默认为 true
+	clearAlias    bool                // 是否清空别名，默认为 true
+	clearMobile   bool                // 是否清空手机号码，默认为 true
+	maxClear      int                 // 单次最多清理的设备数，0 表示不限，默认为 1000
+	maxRatio      float64             // 单次最多清理的设备比例，0 表示不限，默认为 0.5
+	unknownAsIdle bool                // 是否将最后在线时间未知（两天之前）的设备视为不活跃，默认为 false
+	platforms     []platform.Platform // 解析别名时查询的平台，默认为 android、ios、hmos、quickapp
+	dryRun        bool                // 是否仅生成报告而不实际清理，默认为 false
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 不活跃设备清理器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置不活跃设备清理器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发请求数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行的请求数，默认为 4。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置查询与清理请求使用的限速器（详见 api.RateLimiter），默认为每秒 10 次。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 清理属性配置选项。
+type clearOption struct {
+	tags, alias, mobile bool
+}
+
+func (o clearOption) apply(c *config) error {
+	if !o.tags && !o.alias && !o.mobile {
+		return errors.New("at least one of `tags`, `alias` and `mobile` must be cleared")
+	}
+	c.clearTags, c.clearAlias, c.clearMobile = o.tags, o.alias, o.mobile
+	return nil
+}
+
+// 自定义配置需要清空的设备属性，默认全部清空（ClearDeviceAll），否则使用对应的 ClearDevice* 方法。
+func WithClear(tags, alias, mobile bool) ConfigOption {
+	return clearOption{tags, alias, mobile}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 安全限制配置选项。
+type safetyLimitsOption struct {
+	maxClear int
+	maxRatio float64
+}
+
+func (o safetyLimitsOption) apply(c *config) error {
+	if o.maxClear < 0 {
+		return errors.New("`maxClear` cannot be negative")
+	}
+	if o.maxRatio < 0 || o.maxRatio > 1 {
+		return errors.New("`maxRatio` must be in range [0, 1]")
+	}
+	c.maxClear, c.maxRatio = o.maxClear, o.maxRatio
+	return nil
+}
+
+// 自定义配置安全限制：不活跃设备数超过 `maxClear`，或占有效设备数的比例超过 `maxRatio` 时，放弃本次清理（返回 ErrSafetyLimitExceeded）。
+// 默认为 1000 个、50%，为 0 时表示不限。
+//
+// 比例限制可以防止在线状态数据异常（如大量设备被误判为不活跃）时误清理。
+func WithSafetyLimits(maxClear int, maxRatio float64) ConfigOption {
+	return safetyLimitsOption{maxClear, maxRatio}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 未知最后在线时间配置选项。
+type unknownAsInactiveOption bool
+
+func (o unknownAsInactiveOption) apply(c *config) error {
+	c.unknownAsIdle = bool(o)
+	return nil
+}
+
+// 将最后在线时间未知的设备视为不活跃。
+//
+// 「获取用户在线状态」接口对两天之前在线的设备不返回最后在线时间，因此不活跃阈值超过两天时，无法确认这些设备是否超过阈值，
+// 默认将其判定为 VerdictUnknown 而不清理；阈值不超过两天时，这些设备总是被视为不活跃。
+func WithUnknownAsInactive() ConfigOption {
+	return unknownAsInactiveOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 查询平台配置选项。
+type platformsOption []platform.Platform
+
+func (o platformsOption) apply(c *config) error {
+	if len(o) == 0 {
+		return errors.New("`plats` cannot be empty")
+	}
+	for _, p := range o {
+		if p == "" || p == platform.All {
+			return errors.New("`plats` must be specific platforms")
+		}
+	}
+	c.platforms = o
+	return nil
+}
+
+// 自定义配置通过别名扫描时逐个查询的平台，默认为 android、ios、hmos、quickapp。
+func WithPlatforms(plats ...platform.Platform) ConfigOption {
+	return platformsOption(plats)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 试运行配置选项。
+type dryRunOption bool
+
+func (o dryRunOption) apply(c *config) error {
+	c.dryRun = bool(o)
+	return nil
+}
+
+// 开启试运行，只查询在线状态并生成报告，不实际清理设备。
+func WithDryRun() ConfigOption {
+	return dryRunOption(true)
+}
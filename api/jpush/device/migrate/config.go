@@ -0,0 +1,215 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultConcurrency = 4           // 默认的并发迁移数（按目标别名）
+	defaultRateLimit   = 10          // 默认每个限速周期内允许的请求数
+	defaultRatePer     = time.Second // 默认的限速周期
+	maxDevicesPerAlias = 10          // 每个别名最多允许绑定的设备数，超过将报错 7015
+)
+
+// 默认查询的平台。
+var defaultPlatforms = []platform.Platform{platform.Android, platform.IOS, platform.HMOS, platform.QuickApp}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 别名迁移器配置。
+type config struct {
+	logger      jiguang.Logger      // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency int                 // 并发迁移数（按目标别名），默认为 4
+	limiter     *api.RateLimiter    // API 调用限速器，默认为每秒 10 次
+	platforms   []platform.Platform // 逐个查询别名的平台，默认为 android、ios、hmos、quickapp
+	aliasLimit  int                 // 每个别名最多允许绑定的设备数，默认为 10
+	trim        bool                // 超出上限时是否只迁移最近活跃的设备，默认为 false
+	keepOld     bool                // 迁移完成后是否保留旧别名，默认为 false
+	undoLog     io.Writer           // 撤销日志
+	dryRun      bool                // 是否仅生成迁移计划而不实际执行，默认为 false
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 别名迁移器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置别名迁移器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发迁移数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行迁移的目标别名数，默认为 4；迁移到同一目标别名的映射总是依次执行，以保证设备数上限的检查有效。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置别名查询与更新请求使用的限速器（详见 api.RateLimiter），默认为每秒 10 次。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 查询平台配置选项。
+type platformsOption []platform.Platform
+
+func (o platformsOption) apply(c *config) error {
+	if len(o) == 0 {
+		return errors.New("`plats` cannot be empty")
+	}
+	for _, p := range o {
+		if p == "" || p == platform.All {
+			return errors.New("`plats` must be specific platforms")
+		}
+	}
+	c.platforms = o
+	return nil
+}
+
+// 自定义配置逐个查询别名绑定设备的平台，默认为 android、ios、hmos、quickapp。
+//
+// 「查询别名」接口每次最多返回 10 个设备，按平台逐个查询可以找出历史上超出上限绑定的设备。
+func WithPlatforms(plats ...platform.Platform) ConfigOption {
+	return platformsOption(plats)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 别名设备数上限配置选项。
+type aliasLimitOption struct {
+	limit int
+	trim  bool
+}
+
+func (o aliasLimitOption) apply(c *config) error {
+	if o.limit <= 0 {
+		return errors.New("`limit` must be positive")
+	}
+	c.aliasLimit = o.limit
+	c.trim = o.trim
+	return nil
+}
+
+// 自定义配置每个别名最多允许绑定的设备数，默认为 10（极光的限制）。
+//
+// 迁移后目标别名的设备数将超出上限时：`trim` 为 false 则该映射迁移失败（ErrAliasLimitExceeded）；
+// 为 true 则按最后上线日期只迁移最近活跃的设备，其余设备保留在旧别名上（旧别名不会被删除），并记录在 Outcome.Skipped 中。
+func WithAliasLimit(limit int, trim bool) ConfigOption {
+	return aliasLimitOption{limit, trim}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 保留旧别名配置选项。
+type keepOldOption bool
+
+func (o keepOldOption) apply(c *config) error {
+	c.keepOld = bool(o)
+	return nil
+}
+
+// 迁移完成后保留旧别名；默认在旧别名的所有设备迁移完成后通过 DeleteAlias 删除旧别名在查询平台（见 WithPlatforms）上的绑定。
+func WithKeepOld() ConfigOption {
+	return keepOldOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 撤销日志配置选项。
+type undoLogOption struct {
+	w io.Writer
+}
+
+func (o undoLogOption) apply(c *config) error {
+	if o.w == nil {
+		return errors.New("`w` cannot be nil")
+	}
+	c.undoLog = o.w
+	return nil
+}
+
+// 自定义配置撤销日志的输出，迁移每个设备前先以 JSON Lines 格式写入一条 UndoEntry，可通过 Migrator.Rollback 回滚。
+func WithUndoLog(w io.Writer) ConfigOption {
+	return undoLogOption{w}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 试运行配置选项。
+type dryRunOption bool
+
+func (o dryRunOption) apply(c *config) error {
+	c.dryRun = bool(o)
+	return nil
+}
+
+// 开启试运行，只查询别名并计算将迁移的设备，不实际设置或删除别名，也不写入撤销日志。
+func WithDryRun() ConfigOption {
+	return dryRunOption(true)
+}
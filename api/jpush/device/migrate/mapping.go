@@ -0,0 +1,122 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// # 别名映射
+//
+// 将旧别名 From 下的所有设备迁移到新别名 To；多个旧别名可以映射到同一个新别名（如合并账号）。
+type Mapping struct {
+	From string `json:"from"` // 旧别名
+	To   string `json:"to"`   // 新别名
+}
+
+func (m Mapping) String() string {
+	return fmt.Sprintf("%q -> %q", m.From, m.To)
+}
+
+// 按别名格式的变换函数 `fn` 生成别名映射，`fn` 返回空字符串或原别名时忽略该别名。
+//
+// 例如将 "uid_123" 变换为 "u:123"：
+//
+//	mappings := migrate.Rewrite(aliases, func(alias string) string {
+//		if strings.HasPrefix(alias, "uid_") {
+//			return "u:" + strings.TrimPrefix(alias, "uid_")
+//		}
+//		return ""
+//	})
+func Rewrite(aliases []string, fn func(alias string) string) []Mapping {
+	mappings := make([]Mapping, 0, len(aliases))
+	for _, alias := range aliases {
+		if to := fn(alias); to != "" && to != alias {
+			mappings = append(mappings, Mapping{From: alias, To: to})
+		}
+	}
+	return mappings
+}
+
+// # 加载别名映射
+//
+// 从 CSV 读取别名映射，每行依次为旧别名、新别名；首尾空白会被去除，空行和以 # 开头的行会被忽略，首行为 "from,to" 时视为表头。
+func LoadMappings(r io.Reader) ([]Mapping, error) {
+	cr := csv.NewReader(r)
+	cr.Comment = '#'
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	var mappings []Mapping
+	for line := 1; ; line++ {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) != 2 {
+			return nil, fmt.Errorf("record %d: expected 2 fields, got %d", line, len(record))
+		}
+		from, to := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if line == 1 && strings.EqualFold(from, "from") && strings.EqualFold(to, "to") {
+			continue
+		}
+		mappings = append(mappings, Mapping{From: from, To: to})
+	}
+	return mappings, nil
+}
+
+// 从文件加载别名映射，格式同 LoadMappings。
+func LoadMappingsFile(path string) ([]Mapping, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return LoadMappings(bytes.NewReader(data))
+}
+
+// 校验别名映射：别名不能为空，旧别名不能重复，且新别名不能同时作为旧别名（避免链式迁移的顺序歧义）。
+func validateMappings(mappings []Mapping) error {
+	if len(mappings) == 0 {
+		return errors.New("`mappings` cannot be empty")
+	}
+	froms := make(map[string]struct{}, len(mappings))
+	for i, m := range mappings {
+		switch {
+		case m.From == "" || m.To == "":
+			return fmt.Errorf("mapping #%d: aliases cannot be empty", i)
+		case m.From == m.To:
+			return fmt.Errorf("mapping %s: aliases must be different", m)
+		}
+		if _, ok := froms[m.From]; ok {
+			return fmt.Errorf("mapping %s: alias %q is mapped more than once", m, m.From)
+		}
+		froms[m.From] = struct{}{}
+	}
+	for _, m := range mappings {
+		if _, ok := froms[m.To]; ok {
+			return fmt.Errorf("mapping %s: alias %q is also a source alias", m, m.To)
+		}
+	}
+	return nil
+}
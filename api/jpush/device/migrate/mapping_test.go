@@ -0,0 +1,137 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadMappings(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Mapping
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "header comments and blank lines",
+			input: "from,to\n# 注释\n\nuid_1, u:1\n  uid_2 ,u:2  \n",
+			want:  []Mapping{{From: "uid_1", To: "u:1"}, {From: "uid_2", To: "u:2"}},
+		},
+		{
+			name:  "header is case insensitive",
+			input: "FROM,To\nuid_1,u:1\n",
+			want:  []Mapping{{From: "uid_1", To: "u:1"}},
+		},
+		{
+			name:  "header only on first line",
+			input: "uid_1,u:1\nfrom,to\n",
+			want:  []Mapping{{From: "uid_1", To: "u:1"}, {From: "from", To: "to"}},
+		},
+		{
+			name:  "quoted fields",
+			input: "\"a,b\",\"c\"\n",
+			want:  []Mapping{{From: "a,b", To: "c"}},
+		},
+		{
+			name:    "too few fields",
+			input:   "uid_1\n",
+			wantErr: true,
+		},
+		{
+			name:    "too many fields",
+			input:   "uid_1,u:1,x\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed quote",
+			input:   "\"uid_1,u:1\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadMappings(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadMappings() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadMappings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMappings(t *testing.T) {
+	tests := []struct {
+		name     string
+		mappings []Mapping
+		wantErr  string
+	}{
+		{
+			name:     "valid",
+			mappings: []Mapping{{From: "uid_1", To: "u:1"}, {From: "uid_2", To: "u:2"}},
+		},
+		{
+			name:     "merge into one alias",
+			mappings: []Mapping{{From: "uid_1", To: "u:1"}, {From: "uid_2", To: "u:1"}},
+		},
+		{
+			name:    "empty",
+			wantErr: "cannot be empty",
+		},
+		{
+			name:     "empty alias",
+			mappings: []Mapping{{From: "uid_1", To: ""}},
+			wantErr:  "aliases cannot be empty",
+		},
+		{
+			name:     "same alias",
+			mappings: []Mapping{{From: "uid_1", To: "uid_1"}},
+			wantErr:  "must be different",
+		},
+		{
+			name:     "duplicate source",
+			mappings: []Mapping{{From: "uid_1", To: "u:1"}, {From: "uid_1", To: "u:2"}},
+			wantErr:  "mapped more than once",
+		},
+		{
+			name:     "chained mapping",
+			mappings: []Mapping{{From: "uid_1", To: "u:1"}, {From: "u:1", To: "user_1"}},
+			wantErr:  "also a source alias",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMappings(tt.mappings)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateMappings() err = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateMappings() err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
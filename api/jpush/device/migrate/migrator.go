@@ -0,0 +1,407 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+var ErrAliasLimitExceeded = errors.New("alias device limit exceeded") // 迁移后目标别名的设备数将超出上限
+
+// # 别名绑定的设备
+type Device struct {
+	RegistrationID string            // 设备标识 Registration ID
+	Platform       platform.Platform // 平台
+	LastOnline     time.Time         // 最后一次上线日期，未知时为零值
+}
+
+// # 单个别名映射的迁移结果
+type Outcome struct {
+	Mapping  Mapping  // 别名映射
+	Moved    []Device // 已迁移（试运行时为将迁移）的设备
+	Skipped  []Device // 因超出设备数上限而未迁移的设备，仍绑定在旧别名上
+	Existing []Device // 迁移前已绑定在新别名上的设备，试运行时还包含同一新别名下先前映射将迁移的设备
+	Deleted  bool     // 是否已删除旧别名
+	Err      error    // 迁移失败的原因，迁移成功时为 nil；失败前已迁移的设备仍记录在 Moved 中
+}
+
+// # 迁移结果
+type Result struct {
+	DryRun   bool      // 是否为试运行
+	Outcomes []Outcome // 各别名映射的迁移结果，顺序与输入一致
+}
+
+// 迁移失败的别名映射结果。
+func (rs *Result) Failed() []Outcome {
+	if rs == nil {
+		return nil
+	}
+	var failed []Outcome
+	for _, o := range rs.Outcomes {
+		if o.Err != nil {
+			failed = append(failed, o)
+		}
+	}
+	return failed
+}
+
+// 是否所有别名映射均迁移成功。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && len(rs.Failed()) == 0
+}
+
+// 已迁移的设备总数。
+func (rs *Result) Moved() int {
+	n := 0
+	if rs != nil {
+		for _, o := range rs.Outcomes {
+			n += len(o.Moved)
+		}
+	}
+	return n
+}
+
+// # 撤销日志条目
+type UndoEntry struct {
+	RegistrationID string            `json:"registration_id"`    // 设备标识 Registration ID
+	Platform       platform.Platform `json:"platform,omitempty"` // 平台
+	From           string            `json:"from"`               // 迁移前的别名
+	To             string            `json:"to"`                 // 迁移后的别名
+	Time           time.Time         `json:"time"`               // 迁移时间
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 别名迁移器
+//
+// 在合并账号或变更别名格式（如 "uid_123" 变为 "u:123"）时，将旧别名下的所有设备迁移到新别名：
+//  1. 通过 GetAlias 按平台逐个查询旧别名与新别名绑定的设备；
+//  2. 检查迁移后新别名的设备数是否超出上限（默认为 10，见 WithAliasLimit）；
+//  3. 通过 SetDevice 逐个将设备的别名设置为新别名，设置每个设备前先写入撤销日志（见 WithUndoLog）；
+//  4. 旧别名的所有设备迁移完成后，通过 DeleteAlias 删除旧别名在查询平台（见 WithPlatforms）上的绑定，其他平台不受影响（见 WithKeepOld）。
+//
+// 迁移到同一新别名的映射依次执行，不同新别名之间并发执行。
+type Migrator struct {
+	device device.APIv3
+	cfg    config
+	undoMu sync.Mutex
+	undo   *json.Encoder
+}
+
+// 创建新的别名迁移器。
+//   - deviceAPI：【必填】设备 API v3 接口；
+//   - opts：【可选】迁移器配置选项。
+func NewMigrator(deviceAPI device.APIv3, opts ...ConfigOption) (*Migrator, error) {
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		concurrency: defaultConcurrency,
+		platforms:   defaultPlatforms,
+		aliasLimit:  maxDevicesPerAlias,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	m := &Migrator{device: deviceAPI, cfg: c}
+	if c.undoLog != nil {
+		m.undo = json.NewEncoder(c.undoLog)
+	}
+	return m, nil
+}
+
+// 查询别名在所有配置平台上绑定的设备。
+func (m *Migrator) Devices(ctx context.Context, alias string) ([]Device, error) {
+	var (
+		devices []Device
+		seen    = make(map[string]struct{})
+	)
+	for _, p := range m.cfg.platforms {
+		if err := m.cfg.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		result, err := m.device.GetAlias(ctx, alias, p)
+		if err != nil {
+			return nil, err
+		}
+		m.cfg.limiter.Observe(result.Rate)
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, fmt.Errorf("get alias %q on %s: %w", alias, p, err)
+		}
+		for _, d := range result.Data {
+			if _, ok := seen[d.RegistrationID]; ok {
+				continue
+			}
+			seen[d.RegistrationID] = struct{}{}
+			dev := Device{RegistrationID: d.RegistrationID, Platform: d.Platform}
+			if dev.Platform == "" {
+				dev.Platform = p
+			}
+			if d.LastOnlineDate != nil {
+				dev.LastOnline = d.LastOnlineDate.Time
+			}
+			devices = append(devices, dev)
+		}
+	}
+	return devices, nil
+}
+
+// 将旧别名 `from` 下的所有设备迁移到新别名 `to`，等价于只有一个映射的 MigrateAll。
+func (m *Migrator) Migrate(ctx context.Context, from, to string) (*Outcome, error) {
+	result, err := m.MigrateAll(ctx, []Mapping{{From: from, To: to}})
+	if err != nil {
+		return nil, err
+	}
+	o := result.Outcomes[0]
+	return &o, o.Err
+}
+
+// 按别名映射批量迁移设备，映射可通过 LoadMappings 或 Rewrite 生成。
+//
+// 单个映射迁移失败不会中止其余映射，失败的映射可从返回结果的 Failed 中获取。
+func (m *Migrator) MigrateAll(ctx context.Context, mappings []Mapping) (*Result, error) {
+	if err := validateMappings(mappings); err != nil {
+		return nil, err
+	}
+
+	// 按新别名分组，同组依次执行
+	var (
+		groups [][]int
+		byTo   = make(map[string]int)
+	)
+	for i, mp := range mappings {
+		g, ok := byTo[mp.To]
+		if !ok {
+			g = len(groups)
+			byTo[mp.To] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	result := &Result{DryRun: m.cfg.dryRun, Outcomes: make([]Outcome, len(mappings))}
+	api.RunConcurrently(len(groups), m.cfg.concurrency, func(g int) {
+		// 试运行不会真正迁移设备，同组后续映射需要计入先前映射将迁移的设备
+		var planned []Device
+		for _, i := range groups[g] {
+			result.Outcomes[i] = m.migrate(ctx, mappings[i], planned)
+			if m.cfg.dryRun {
+				planned = append(planned, result.Outcomes[i].Moved...)
+			}
+		}
+	})
+
+	failed := len(result.Failed())
+	m.cfg.logger.Infof(ctx, "别名迁移完成：共 %d 条映射，迁移 %d 个设备，失败 %d 条（预演：%t）",
+		len(mappings), result.Moved(), failed, m.cfg.dryRun)
+	return result, nil
+}
+
+// # 回滚迁移
+//
+// 读取撤销日志（WithUndoLog 的输出），按相反顺序将设备的别名恢复为迁移前的别名；当前别名已不是迁移后别名的设备（迁移后又被修改）会被跳过。
+// 回滚时不会写入撤销日志；配置了 WithDryRun 时只返回将回滚的条目。
+//
+// 返回已回滚（或将回滚）的条目与跳过的条目，遇到错误时中止并返回已处理的部分。
+func (m *Migrator) Rollback(ctx context.Context, undoLog io.Reader) (rolledBack, skipped []UndoEntry, err error) {
+	var entries []UndoEntry
+	scanner := bufio.NewScanner(undoLog)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var e UndoEntry
+		if err = json.Unmarshal(line, &e); err != nil {
+			return nil, nil, err
+		}
+		entries = append(entries, e)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		alias, err := m.currentAlias(ctx, e.RegistrationID)
+		if err != nil {
+			return rolledBack, skipped, fmt.Errorf("get device %s: %w", e.RegistrationID, err)
+		}
+		if alias != e.To {
+			m.cfg.logger.Warnf(ctx, "跳过设备 %s 的回滚：当前别名为 %s，不是迁移后的别名 %s", e.RegistrationID, alias, e.To)
+			skipped = append(skipped, e)
+			continue
+		}
+		if !m.cfg.dryRun {
+			if err = m.setAlias(ctx, e.RegistrationID, e.From); err != nil {
+				return rolledBack, skipped, fmt.Errorf("restore alias of %s: %w", e.RegistrationID, err)
+			}
+		}
+		rolledBack = append(rolledBack, e)
+	}
+	m.cfg.logger.Infof(ctx, "别名回滚完成：恢复 %d 个设备，跳过 %d 个设备（预演：%t）", len(rolledBack), len(skipped), m.cfg.dryRun)
+	return rolledBack, skipped, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (m *Migrator) migrate(ctx context.Context, mp Mapping, planned []Device) (o Outcome) {
+	o.Mapping = mp
+	defer func() {
+		if o.Err != nil {
+			m.cfg.logger.Errorf(ctx, "别名迁移 %s 失败：%s", mp, o.Err)
+		}
+	}()
+
+	moving, err := m.Devices(ctx, mp.From)
+	if err != nil {
+		o.Err = err
+		return
+	}
+	if o.Existing, err = m.Devices(ctx, mp.To); err != nil {
+		o.Err = err
+		return
+	}
+	o.Existing = mergeDevices(o.Existing, planned)
+
+	if free := m.cfg.aliasLimit - len(o.Existing); len(moving) > free {
+		if !m.cfg.trim {
+			o.Err = fmt.Errorf("%w: %d devices on %q, %d to move, limit %d", ErrAliasLimitExceeded, len(o.Existing), mp.To, len(moving), m.cfg.aliasLimit)
+			return
+		}
+		if free < 0 {
+			free = 0
+		}
+		sort.SliceStable(moving, func(i, j int) bool { return moving[i].LastOnline.After(moving[j].LastOnline) })
+		moving, o.Skipped = moving[:free], moving[free:]
+	}
+
+	if m.cfg.dryRun {
+		o.Moved = moving
+		return
+	}
+	for _, d := range moving {
+		// 先写入撤销日志再设置别名，确保已迁移的设备都能回滚；设置失败的设备在回滚时会因别名未变更而被跳过
+		if o.Err = m.writeUndo(UndoEntry{RegistrationID: d.RegistrationID, Platform: d.Platform, From: mp.From, To: mp.To, Time: time.Now()}); o.Err != nil {
+			o.Err = fmt.Errorf("write undo log: %w", o.Err)
+			return
+		}
+		if o.Err = m.setAlias(ctx, d.RegistrationID, mp.To); o.Err != nil {
+			o.Err = fmt.Errorf("set alias of %s: %w", d.RegistrationID, o.Err)
+			return
+		}
+		o.Moved = append(o.Moved, d)
+	}
+
+	if !m.cfg.keepOld && len(o.Skipped) == 0 {
+		if o.Err = m.deleteAlias(ctx, mp.From); o.Err != nil {
+			o.Err = fmt.Errorf("delete alias %q: %w", mp.From, o.Err)
+			return
+		}
+		o.Deleted = true
+	}
+	m.cfg.logger.Infof(ctx, "别名迁移 %s 完成：迁移 %d 个设备，跳过 %d 个设备", mp, len(o.Moved), len(o.Skipped))
+	return
+}
+
+func (m *Migrator) setAlias(ctx context.Context, regID, alias string) error {
+	if err := m.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	result, err := m.device.SetDevice(ctx, regID, &device.DeviceSetParam{Alias: &alias})
+	if err != nil {
+		return err
+	}
+	m.cfg.limiter.Observe(result.Rate)
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+func (m *Migrator) deleteAlias(ctx context.Context, alias string) error {
+	if err := m.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	result, err := m.device.DeleteAlias(ctx, alias, m.cfg.platforms...)
+	if err != nil {
+		return err
+	}
+	m.cfg.limiter.Observe(result.Rate)
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+func (m *Migrator) currentAlias(ctx context.Context, regID string) (string, error) {
+	if err := m.cfg.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	result, err := m.device.GetDevice(ctx, regID)
+	if err != nil {
+		return "", err
+	}
+	m.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return "", err
+	}
+	return result.Alias, nil
+}
+
+func (m *Migrator) writeUndo(e UndoEntry) error {
+	if m.undo == nil {
+		return nil
+	}
+	m.undoMu.Lock()
+	defer m.undoMu.Unlock()
+	return m.undo.Encode(e)
+}
+
+// 将 `extra` 中不在 `devices` 里的设备追加到 `devices` 之后。
+func mergeDevices(devices, extra []Device) []Device {
+	if len(extra) == 0 {
+		return devices
+	}
+	seen := make(map[string]struct{}, len(devices))
+	for _, d := range devices {
+		seen[d.RegistrationID] = struct{}{}
+	}
+	for _, d := range extra {
+		if _, ok := seen[d.RegistrationID]; !ok {
+			seen[d.RegistrationID] = struct{}{}
+			devices = append(devices, d)
+		}
+	}
+	return devices
+}
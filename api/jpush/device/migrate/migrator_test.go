@@ -0,0 +1,239 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 内存中的设备 API，只实现迁移器用到的接口。
+type fakeDevice struct {
+	device.APIv3
+
+	mu      sync.Mutex
+	aliases map[string]string              // Registration ID -> 别名
+	online  map[string]string              // Registration ID -> 最后上线日期
+	failSet map[string]bool                // SetDevice 失败的 Registration ID
+	undo    *bytes.Buffer                  // SetDevice 时撤销日志的内容快照
+	written map[string]bool                // SetDevice 时撤销日志是否已包含该设备
+	deleted map[string][]platform.Platform // DeleteAlias 删除的别名及平台
+}
+
+func newFakeDevice(aliases map[string]string) *fakeDevice {
+	return &fakeDevice{aliases: aliases, online: map[string]string{}, failSet: map[string]bool{}, written: map[string]bool{},
+		deleted: map[string][]platform.Platform{}}
+}
+
+func ok() *api.Response { return &api.Response{StatusCode: 200} }
+
+func (f *fakeDevice) GetAlias(_ context.Context, alias string, _ ...platform.Platform) (*device.AliasGetResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	result := &device.AliasGetResult{Response: ok()}
+	regIDs := make([]string, 0, len(f.aliases))
+	for regID := range f.aliases {
+		regIDs = append(regIDs, regID)
+	}
+	sort.Strings(regIDs)
+	for _, regID := range regIDs {
+		if f.aliases[regID] != alias {
+			continue
+		}
+		d := device.AliasGetData{RegistrationID: regID, Platform: platform.Android}
+		if date, ok := f.online[regID]; ok {
+			ld, _ := jiguang.ParseLocalDate(date)
+			d.LastOnlineDate = &ld
+		}
+		result.Data = append(result.Data, d)
+	}
+	return result, nil
+}
+
+func (f *fakeDevice) GetDevice(_ context.Context, regID string) (*device.DeviceGetResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &device.DeviceGetResult{Response: ok(), Alias: f.aliases[regID]}, nil
+}
+
+func (f *fakeDevice) SetDevice(_ context.Context, regID string, param *device.DeviceSetParam) (*device.DeviceSetResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.undo != nil {
+		f.written[regID] = bytes.Contains(f.undo.Bytes(), []byte(`"registration_id":"`+regID+`"`))
+	}
+	if f.failSet[regID] {
+		return nil, errors.New("set device failed")
+	}
+	f.aliases[regID] = *param.Alias
+	return &device.DeviceSetResult{Response: ok()}, nil
+}
+
+func (f *fakeDevice) DeleteAlias(_ context.Context, alias string, plats ...platform.Platform) (*device.AliasDeleteResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted[alias] = plats
+	return &device.AliasDeleteResult{Response: ok()}, nil
+}
+
+func newTestMigrator(t *testing.T, f *fakeDevice, opts ...ConfigOption) *Migrator {
+	t.Helper()
+	opts = append([]ConfigOption{WithPlatforms(platform.Android)}, opts...)
+	m, err := NewMigrator(f, opts...)
+	if err != nil {
+		t.Fatalf("new migrator: %v", err)
+	}
+	return m
+}
+
+func TestMigrateAllDryRunGroup(t *testing.T) {
+	f := newFakeDevice(map[string]string{"a1": "uid_1", "a2": "uid_1", "b1": "uid_2", "c1": "u:1"})
+	m := newTestMigrator(t, f, WithDryRun(), WithAliasLimit(3, false))
+
+	result, err := m.MigrateAll(context.Background(), []Mapping{{From: "uid_1", To: "u:1"}, {From: "uid_2", To: "u:1"}})
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	first, second := result.Outcomes[0], result.Outcomes[1]
+	if first.Err != nil || len(first.Moved) != 2 || len(first.Existing) != 1 {
+		t.Fatalf("first outcome = %+v, want 2 moved and 1 existing", first)
+	}
+	// 第二个映射需要计入第一个映射将迁移的设备：1 + 2 + 1 > 3
+	if !errors.Is(second.Err, ErrAliasLimitExceeded) {
+		t.Fatalf("second outcome err = %v, want %v", second.Err, ErrAliasLimitExceeded)
+	}
+	if len(second.Existing) != 3 {
+		t.Errorf("second outcome existing = %d, want 3", len(second.Existing))
+	}
+	if f.aliases["a1"] != "uid_1" {
+		t.Errorf("dry run changed alias of a1 to %q", f.aliases["a1"])
+	}
+}
+
+func TestMigrateUndoBeforeSet(t *testing.T) {
+	var undo bytes.Buffer
+	f := newFakeDevice(map[string]string{"a1": "uid_1", "a2": "uid_1"})
+	f.undo, f.failSet["a2"] = &undo, true
+	m := newTestMigrator(t, f, WithUndoLog(&undo))
+
+	o, err := m.Migrate(context.Background(), "uid_1", "u:1")
+	if err == nil {
+		t.Fatal("migrate: expected error")
+	}
+	for _, regID := range []string{"a1", "a2"} {
+		if !f.written[regID] {
+			t.Errorf("undo entry of %s was not written before SetDevice", regID)
+		}
+	}
+	if len(o.Moved) != 1 || o.Deleted {
+		t.Errorf("outcome = %+v, want 1 moved and old alias kept", o)
+	}
+
+	// 设置失败的设备别名未变更，回滚时被跳过
+	rolledBack, skipped, err := m.Rollback(context.Background(), bytes.NewReader(undo.Bytes()))
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if len(rolledBack) != 1 || rolledBack[0].RegistrationID != "a1" {
+		t.Errorf("rolled back = %+v, want a1", rolledBack)
+	}
+	if len(skipped) != 1 || skipped[0].RegistrationID != "a2" {
+		t.Errorf("skipped = %+v, want a2", skipped)
+	}
+	if f.aliases["a1"] != "uid_1" {
+		t.Errorf("alias of a1 = %q, want %q", f.aliases["a1"], "uid_1")
+	}
+}
+
+func TestMigrateDeleteAliasOnScannedPlatforms(t *testing.T) {
+	f := newFakeDevice(map[string]string{"a1": "uid_1"})
+	m := newTestMigrator(t, f, WithPlatforms(platform.Android, platform.IOS))
+
+	o, err := m.Migrate(context.Background(), "uid_1", "u:1")
+	if err != nil || !o.Deleted {
+		t.Fatalf("migrate: err = %v, deleted = %v", err, o.Deleted)
+	}
+	want := []platform.Platform{platform.Android, platform.IOS}
+	if got, ok := f.deleted["uid_1"]; !ok || !reflect.DeepEqual(got, want) {
+		t.Errorf("DeleteAlias platforms = %v, want %v", got, want)
+	}
+}
+
+func TestMigrateTrimRecentFirst(t *testing.T) {
+	tests := []struct {
+		name        string
+		limit       int
+		wantMoved   []string
+		wantSkipped []string
+	}{
+		{name: "within limit", limit: 5, wantMoved: []string{"a1", "a2", "a3", "a4"}},
+		{name: "keep most recent", limit: 3, wantMoved: []string{"a3", "a1"}, wantSkipped: []string{"a4", "a2"}},
+		{name: "target already full", limit: 1, wantSkipped: []string{"a3", "a1", "a4", "a2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeDevice(map[string]string{"a1": "uid_1", "a2": "uid_1", "a3": "uid_1", "a4": "uid_1", "b1": "u:1"})
+			// 按最后上线日期倒序迁移，没有上线日期的 a2 排在最后
+			f.online["a1"], f.online["a3"], f.online["a4"] = "2025-03-01", "2025-05-01", "2025-01-01"
+			m := newTestMigrator(t, f, WithAliasLimit(tt.limit, true))
+
+			o, err := m.Migrate(context.Background(), "uid_1", "u:1")
+			if err != nil {
+				t.Fatalf("migrate: %v", err)
+			}
+			if got := regIDs(o.Moved); !equalStrings(got, tt.wantMoved) {
+				t.Errorf("moved = %v, want %v", got, tt.wantMoved)
+			}
+			if got := regIDs(o.Skipped); !equalStrings(got, tt.wantSkipped) {
+				t.Errorf("skipped = %v, want %v", got, tt.wantSkipped)
+			}
+			for _, regID := range tt.wantSkipped {
+				if f.aliases[regID] != "uid_1" {
+					t.Errorf("alias of skipped %s = %q, want %q", regID, f.aliases[regID], "uid_1")
+				}
+			}
+		})
+	}
+}
+
+func regIDs(devices []Device) []string {
+	ids := make([]string, 0, len(devices))
+	for _, d := range devices {
+		ids = append(ids, d.RegistrationID)
+	}
+	return ids
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
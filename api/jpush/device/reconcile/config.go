@@ -0,0 +1,153 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultConcurrency = 4           // 默认的并发请求数
+	defaultRateLimit   = 10          // 默认每个限速周期内允许的请求数
+	defaultRatePer     = time.Second // 默认的限速周期
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 设备状态调和器配置。
+type config struct {
+	logger      jiguang.Logger   // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency int              // 并发请求数，默认为 4
+	limiter     *api.RateLimiter // API 调用限速器，默认为每秒 10 次
+	tagPrefix   string           // 受管标签的前缀，默认为空，表示设备的所有标签均受管
+	tagBatching bool             // 是否按标签聚合设备后通过 SetTag 批量更新标签，默认为 false
+	dryRun      bool             // 是否仅打印执行计划而不实际执行，默认为 false
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 设备状态调和器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置设备状态调和器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发请求数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行的请求数（查询与更新），默认为 4。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置请求（查询与更新）使用的限速器（详见 api.RateLimiter），默认为每秒 10 次。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 受管标签前缀配置选项。
+type tagPrefixOption string
+
+func (o tagPrefixOption) apply(c *config) error {
+	c.tagPrefix = string(o)
+	return nil
+}
+
+// 自定义配置受管标签的前缀，只有以 `prefix` 开头的标签会被添加或删除，设备上的其他标签（如客户端自行设置的标签）保持不变；
+// 期望状态中的标签也必须以 `prefix` 开头。默认为空，表示设备的所有标签均受管。
+func WithTagPrefix(prefix string) ConfigOption {
+	return tagPrefixOption(prefix)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 标签批量更新配置选项。
+type tagBatchingOption bool
+
+func (o tagBatchingOption) apply(c *config) error {
+	c.tagBatching = bool(o)
+	return nil
+}
+
+// 开启后，标签的添加和删除按标签聚合所有设备，通过 SetTag 每次最多更新 1000 个设备，大量设备变更相同标签时可显著减少请求数；
+// 别名与手机号码仍按设备更新。批量请求失败时，该批次中的所有设备均记为失败。
+func WithTagBatching() ConfigOption {
+	return tagBatchingOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 试运行配置选项。
+type dryRunOption bool
+
+func (o dryRunOption) apply(c *config) error {
+	c.dryRun = bool(o)
+	return nil
+}
+
+// 开启试运行，Apply 仅打印执行计划而不实际执行变更；查询设备的当前状态仍会正常进行。
+func WithDryRun() ConfigOption {
+	return dryRunOption(true)
+}
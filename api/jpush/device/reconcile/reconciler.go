@@ -0,0 +1,586 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+)
+
+const (
+	maxTagsPerSet       = 100  // SetDevice 每次添加或删除的标签数上限
+	maxTagBytesPerSet   = 1000 // SetDevice 每次添加或删除的标签总长度上限（字节）
+	maxRegIDsPerTagCall = 1000 // SetTag 每次添加或删除的 Registration ID 数量上限
+)
+
+// # 设备的期望状态
+//
+// 字段为 nil 表示不管理该属性，保持设备当前的值不变。
+type Desired struct {
+	RegistrationID string   // 【必填】设备标识 Registration ID
+	Tags           []string // 【可选】期望的（受管）标签集合，空切片表示应没有（受管）标签
+	Alias          *string  // 【可选】期望的别名，空字符串表示应没有别名
+	Mobile         *string  // 【可选】期望的手机号码，空字符串表示应没有手机号码
+}
+
+// # 设备的当前状态
+type State struct {
+	Tags   []string // 标签
+	Alias  string   // 别名
+	Mobile string   // 手机号码
+}
+
+// # 单个设备的变更
+type Change struct {
+	RegistrationID string   // 设备标识 Registration ID
+	AddTags        []string // 需要添加的标签（已排序）
+	RemoveTags     []string // 需要删除的标签（已排序）
+	Alias          *string  // 需要设置的别名，空字符串表示清空别名，nil 表示无需变更
+	Mobile         *string  // 需要设置的手机号码，空字符串表示清空手机号码，nil 表示无需变更
+	Actual         State    // 变更前的状态
+}
+
+func (c Change) String() string {
+	var parts []string
+	if len(c.AddTags) > 0 {
+		parts = append(parts, "+tags["+strings.Join(c.AddTags, ",")+"]")
+	}
+	if len(c.RemoveTags) > 0 {
+		parts = append(parts, "-tags["+strings.Join(c.RemoveTags, ",")+"]")
+	}
+	if c.Alias != nil {
+		parts = append(parts, fmt.Sprintf("alias %q -> %q", c.Actual.Alias, *c.Alias))
+	}
+	if c.Mobile != nil {
+		parts = append(parts, fmt.Sprintf("mobile %q -> %q", c.Actual.Mobile, *c.Mobile))
+	}
+	return c.RegistrationID + ": " + strings.Join(parts, ", ")
+}
+
+// 是否需要清空设备的所有标签。
+func (c Change) clearsTags() bool {
+	return len(c.AddTags) == 0 && len(c.RemoveTags) > 0 && len(c.RemoveTags) == len(c.Actual.Tags)
+}
+
+// # 查询设备状态失败信息
+type DeviceError struct {
+	RegistrationID string // 设备标识 Registration ID
+	Err            error  // 失败原因
+}
+
+// # 执行计划
+type Plan struct {
+	Changes   []Change      // 变更列表，按期望状态的声明顺序排列
+	Unchanged int           // 与期望一致、无需变更的设备数
+	Errors    []DeviceError // 查询当前状态失败的设备，这些设备不会出现在变更列表中
+}
+
+// # 变更汇总
+type Summary struct {
+	Devices        int // 需要变更的设备数
+	Unchanged      int // 无需变更的设备数
+	Errors         int // 查询当前状态失败的设备数
+	TagsAdded      int // 添加的标签数（按设备累计）
+	TagsRemoved    int // 删除的标签数（按设备累计）
+	AliasesSet     int // 设置的别名数
+	AliasesCleared int // 清空的别名数
+	MobilesSet     int // 设置的手机号码数
+	MobilesCleared int // 清空的手机号码数
+}
+
+// 是否有需要执行的变更。
+func (p *Plan) HasChanges() bool {
+	return p != nil && len(p.Changes) > 0
+}
+
+// 执行计划的变更汇总。
+func (p *Plan) Summary() Summary {
+	var s Summary
+	if p == nil {
+		return s
+	}
+	s.Devices, s.Unchanged, s.Errors = len(p.Changes), p.Unchanged, len(p.Errors)
+	for _, c := range p.Changes {
+		s.TagsAdded += len(c.AddTags)
+		s.TagsRemoved += len(c.RemoveTags)
+		if c.Alias != nil {
+			if *c.Alias == "" {
+				s.AliasesCleared++
+			} else {
+				s.AliasesSet++
+			}
+		}
+		if c.Mobile != nil {
+			if *c.Mobile == "" {
+				s.MobilesCleared++
+			} else {
+				s.MobilesSet++
+			}
+		}
+	}
+	return s
+}
+
+func (s Summary) String() string {
+	return fmt.Sprintf("Plan: %d devices to change (+%d/-%d tags, %d/%d aliases set/cleared, %d/%d mobiles set/cleared), %d unchanged, %d errors.",
+		s.Devices, s.TagsAdded, s.TagsRemoved, s.AliasesSet, s.AliasesCleared, s.MobilesSet, s.MobilesCleared, s.Unchanged, s.Errors)
+}
+
+// 执行计划的可读文本，可用于审阅或在定时任务日志中展示。
+func (p *Plan) String() string {
+	if p == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	for _, e := range p.Errors {
+		fmt.Fprintf(&b, "%s: error: %v\n", e.RegistrationID, e.Err)
+	}
+	b.WriteString(p.Summary().String())
+	return b.String()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 单个设备变更的执行结果
+type Outcome struct {
+	Change   Change // 变更
+	Requests int    // 发起的请求数，批量更新标签的请求不计入
+	Err      error  // 执行失败的原因，执行成功时为 nil
+}
+
+// # 执行结果
+type Result struct {
+	DryRun   bool      // 是否为试运行
+	Outcomes []Outcome // 各设备变更的执行结果
+}
+
+// 执行失败的变更结果。
+func (rs *Result) Failed() []Outcome {
+	if rs == nil {
+		return nil
+	}
+	var failed []Outcome
+	for _, o := range rs.Outcomes {
+		if o.Err != nil {
+			failed = append(failed, o)
+		}
+	}
+	return failed
+}
+
+// 是否所有变更均执行成功。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && len(rs.Failed()) == 0
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 设备状态调和器
+//
+// 以业务系统（如用户数据库）为准，将每个设备期望的标签、别名与手机号码和通过 GetDevice 查询到的当前状态比对，
+// 生成最小变更的执行计划，并通过 SetDevice、ClearDevice*（或开启 WithTagBatching 时的 SetTag）执行，适合作为每日定时对账任务运行。
+//
+// 变更规则：
+//   - 标签：添加期望中有而设备上没有的标签，删除设备上有而期望中没有的（受管）标签；需要删除设备的所有标签时直接清空；
+//   - 别名与手机号码：与期望不一致时设置为期望值，期望为空字符串时清空；
+//   - 只需清空属性时使用对应的 ClearDevice* 方法，其余情况使用 SetDevice，标签超出单次上限时自动拆分为多次请求。
+type Reconciler struct {
+	device device.APIv3
+	cfg    config
+}
+
+// 创建新的设备状态调和器。
+//   - deviceAPI：【必填】设备 API v3 接口；
+//   - opts：【可选】调和器配置选项。
+func NewReconciler(deviceAPI device.APIv3, opts ...ConfigOption) (*Reconciler, error) {
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		concurrency: defaultConcurrency,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Reconciler{device: deviceAPI, cfg: c}, nil
+}
+
+// 查询设备的当前状态并与期望状态比对，生成执行计划。
+//
+// 单个设备查询失败不会中止其余设备，失败的设备可从执行计划的 Errors 中获取。
+func (r *Reconciler) Plan(ctx context.Context, desired []Desired) (*Plan, error) {
+	if err := r.validate(desired); err != nil {
+		return nil, err
+	}
+
+	states := make([]*State, len(desired))
+	errs := make([]error, len(desired))
+	api.RunConcurrently(len(desired), r.cfg.concurrency, func(i int) {
+		states[i], errs[i] = r.get(ctx, desired[i].RegistrationID)
+	})
+
+	plan := &Plan{}
+	for i, d := range desired {
+		if errs[i] != nil {
+			r.cfg.logger.Errorf(ctx, "查询设备 %s 失败：%s", d.RegistrationID, errs[i])
+			plan.Errors = append(plan.Errors, DeviceError{RegistrationID: d.RegistrationID, Err: errs[i]})
+			continue
+		}
+		if c, changed := r.diff(d, *states[i]); changed {
+			plan.Changes = append(plan.Changes, c)
+		} else {
+			plan.Unchanged++
+		}
+	}
+	return plan, nil
+}
+
+// 执行计划中的变更；配置了 WithDryRun 时仅打印执行计划。
+//
+// 单个设备变更失败不会中止其余设备，失败的变更可从返回结果的 Failed 中获取。
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	if plan == nil {
+		return nil, errors.New("`plan` cannot be nil")
+	}
+
+	result := &Result{DryRun: r.cfg.dryRun, Outcomes: make([]Outcome, len(plan.Changes))}
+	for i, c := range plan.Changes {
+		result.Outcomes[i].Change = c
+	}
+	if r.cfg.dryRun {
+		r.cfg.logger.Infof(ctx, "设备调和预演：\n%s", plan)
+		return result, nil
+	}
+
+	var tagErrs map[string]error
+	if r.cfg.tagBatching {
+		tagErrs = r.applyTagBatches(ctx, plan.Changes)
+	}
+	api.RunConcurrently(len(plan.Changes), r.cfg.concurrency, func(i int) {
+		o := &result.Outcomes[i]
+		o.Requests, o.Err = r.apply(ctx, o.Change, r.cfg.tagBatching)
+		if o.Err == nil {
+			o.Err = tagErrs[o.Change.RegistrationID]
+		}
+	})
+
+	failed := 0
+	for _, o := range result.Outcomes {
+		if o.Err != nil {
+			failed++
+			r.cfg.logger.Errorf(ctx, "执行变更 %s 失败：%s", o.Change, o.Err)
+		}
+	}
+	r.cfg.logger.Infof(ctx, "设备调和完成：成功 %d 个，失败 %d 个", len(result.Outcomes)-failed, failed)
+	return result, nil
+}
+
+// 生成执行计划并执行，等价于 Plan 后调用 Apply。
+func (r *Reconciler) Reconcile(ctx context.Context, desired []Desired) (*Plan, *Result, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := r.Apply(ctx, plan)
+	return plan, result, err
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (r *Reconciler) validate(desired []Desired) error {
+	seen := make(map[string]struct{}, len(desired))
+	for i, d := range desired {
+		if d.RegistrationID == "" {
+			return fmt.Errorf("device #%d: registration id cannot be empty", i)
+		}
+		if _, ok := seen[d.RegistrationID]; ok {
+			return fmt.Errorf("device %s: declared more than once", d.RegistrationID)
+		}
+		seen[d.RegistrationID] = struct{}{}
+		for _, tag := range d.Tags {
+			if tag == "" {
+				return fmt.Errorf("device %s: tag cannot be empty", d.RegistrationID)
+			}
+			if !strings.HasPrefix(tag, r.cfg.tagPrefix) {
+				return fmt.Errorf("device %s: tag %q must start with %q", d.RegistrationID, tag, r.cfg.tagPrefix)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) get(ctx context.Context, regID string) (*State, error) {
+	if err := r.cfg.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.device.GetDevice(ctx, regID)
+	if err != nil {
+		return nil, err
+	}
+	r.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	return &State{Tags: result.Tags, Alias: result.Alias, Mobile: result.Mobile}, nil
+}
+
+func (r *Reconciler) diff(d Desired, actual State) (Change, bool) {
+	c := Change{RegistrationID: d.RegistrationID, Actual: actual}
+	if d.Tags != nil {
+		want := make(map[string]struct{}, len(d.Tags))
+		for _, tag := range d.Tags {
+			want[tag] = struct{}{}
+		}
+		have := make(map[string]struct{}, len(actual.Tags))
+		for _, tag := range actual.Tags {
+			have[tag] = struct{}{}
+			if _, ok := want[tag]; !ok && strings.HasPrefix(tag, r.cfg.tagPrefix) {
+				c.RemoveTags = append(c.RemoveTags, tag)
+			}
+		}
+		for tag := range want {
+			if _, ok := have[tag]; !ok {
+				c.AddTags = append(c.AddTags, tag)
+			}
+		}
+		sort.Strings(c.AddTags)
+		sort.Strings(c.RemoveTags)
+	}
+	if d.Alias != nil && *d.Alias != actual.Alias {
+		alias := *d.Alias
+		c.Alias = &alias
+	}
+	if d.Mobile != nil && *d.Mobile != actual.Mobile {
+		mobile := *d.Mobile
+		c.Mobile = &mobile
+	}
+	changed := len(c.AddTags) > 0 || len(c.RemoveTags) > 0 || c.Alias != nil || c.Mobile != nil
+	return c, changed
+}
+
+// 执行单个设备的变更，返回发起的请求数；`skipTags` 为 true 时标签已由 applyTagBatches 处理。
+func (r *Reconciler) apply(ctx context.Context, c Change, skipTags bool) (int, error) {
+	var (
+		clearTags   = !skipTags && c.clearsTags()
+		clearAlias  = c.Alias != nil && *c.Alias == ""
+		clearMobile = c.Mobile != nil && *c.Mobile == ""
+		setAlias    = c.Alias != nil && *c.Alias != ""
+		setMobile   = c.Mobile != nil && *c.Mobile != ""
+		adds, rems  [][]string
+	)
+	if !skipTags && !clearTags {
+		adds, rems = batchTags(c.AddTags), batchTags(c.RemoveTags)
+	}
+
+	// 只需清空属性
+	if !setAlias && !setMobile && len(adds) == 0 && len(rems) == 0 {
+		if !clearTags && !clearAlias && !clearMobile {
+			return 0, nil
+		}
+		return 1, r.clear(ctx, c.RegistrationID, clearTags, clearAlias, clearMobile)
+	}
+
+	requests := 0
+	for i := 0; i == 0 || i < len(adds) || i < len(rems); i++ {
+		param := &device.DeviceSetParam{}
+		if i == 0 {
+			param.Alias, param.Mobile = c.Alias, c.Mobile
+			if clearTags {
+				param.Tags = ""
+			}
+		}
+		var tags device.TagsForDeviceSetParam
+		if i < len(adds) {
+			tags.Add = adds[i]
+		}
+		if i < len(rems) {
+			tags.Remove = rems[i]
+		}
+		if len(tags.Add) > 0 || len(tags.Remove) > 0 {
+			param.Tags = &tags
+		}
+
+		requests++
+		if err := r.set(ctx, c.RegistrationID, param); err != nil {
+			return requests, err
+		}
+	}
+	return requests, nil
+}
+
+func (r *Reconciler) set(ctx context.Context, regID string, param *device.DeviceSetParam) error {
+	if err := r.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	result, err := r.device.SetDevice(ctx, regID, param)
+	if err != nil {
+		return err
+	}
+	r.cfg.limiter.Observe(result.Rate)
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+func (r *Reconciler) clear(ctx context.Context, regID string, tags, alias, mobile bool) error {
+	if err := r.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	var clearFunc func(context.Context, string) (*device.DeviceClearResult, error)
+	switch {
+	case tags && alias && mobile:
+		clearFunc = r.device.ClearDeviceAll
+	case tags && alias:
+		clearFunc = r.device.ClearDeviceTagsAndAlias
+	case tags && mobile:
+		clearFunc = r.device.ClearDeviceTagsAndMobile
+	case alias && mobile:
+		clearFunc = r.device.ClearDeviceAliasAndMobile
+	case tags:
+		clearFunc = r.device.ClearDeviceTags
+	case alias:
+		clearFunc = r.device.ClearDeviceAlias
+	default:
+		clearFunc = r.device.ClearDeviceMobile
+	}
+	result, err := clearFunc(ctx, regID)
+	if err != nil {
+		return err
+	}
+	r.cfg.limiter.Observe(result.Rate)
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+// 按标签聚合所有设备的标签变更，通过 SetTag 批量执行，返回失败设备的错误，key 为 Registration ID。
+func (r *Reconciler) applyTagBatches(ctx context.Context, changes []Change) map[string]error {
+	type batch struct {
+		tag           string
+		adds, removes []string
+	}
+	adds, removes := make(map[string][]string), make(map[string][]string)
+	for _, c := range changes {
+		for _, tag := range c.AddTags {
+			adds[tag] = append(adds[tag], c.RegistrationID)
+		}
+		for _, tag := range c.RemoveTags {
+			removes[tag] = append(removes[tag], c.RegistrationID)
+		}
+	}
+	tags := make([]string, 0, len(adds)+len(removes))
+	for tag := range adds {
+		tags = append(tags, tag)
+	}
+	for tag := range removes {
+		if _, ok := adds[tag]; !ok {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+
+	var batches []batch
+	for _, tag := range tags {
+		a, rm := api.ChunkStrings(adds[tag], maxRegIDsPerTagCall), api.ChunkStrings(removes[tag], maxRegIDsPerTagCall)
+		for i := 0; i < len(a) || i < len(rm); i++ {
+			b := batch{tag: tag}
+			if i < len(a) {
+				b.adds = a[i]
+			}
+			if i < len(rm) {
+				b.removes = rm[i]
+			}
+			batches = append(batches, b)
+		}
+	}
+
+	var (
+		mu   sync.Mutex
+		errs = make(map[string]error)
+	)
+	api.RunConcurrently(len(batches), r.cfg.concurrency, func(i int) {
+		b := batches[i]
+		err := r.setTag(ctx, b.tag, b.adds, b.removes)
+		if err == nil {
+			return
+		}
+		err = fmt.Errorf("set tag %q: %w", b.tag, err)
+		mu.Lock()
+		defer mu.Unlock()
+		for _, regIDs := range [][]string{b.adds, b.removes} {
+			for _, regID := range regIDs {
+				if _, ok := errs[regID]; !ok {
+					errs[regID] = err
+				}
+			}
+		}
+	})
+	return errs
+}
+
+func (r *Reconciler) setTag(ctx context.Context, tag string, adds, removes []string) error {
+	if err := r.cfg.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	result, err := r.device.SetTag(ctx, tag, adds, removes)
+	if err != nil {
+		return err
+	}
+	r.cfg.limiter.Observe(result.Rate)
+	if result.Error != nil && !result.Error.IsSuccess() {
+		return result.Error
+	}
+	return api.CheckResponse(result.Response, nil)
+}
+
+// 按 SetDevice 的单次上限（100 个、总长度 1000 字节）拆分标签。
+func batchTags(tags []string) [][]string {
+	var (
+		batches [][]string
+		cur     []string
+		size    int
+	)
+	for _, tag := range tags {
+		if len(cur) == maxTagsPerSet || (len(cur) > 0 && size+len(tag) > maxTagBytesPerSet) {
+			batches = append(batches, cur)
+			cur, size = nil, 0
+		}
+		cur = append(cur, tag)
+		size += len(tag)
+	}
+	if len(cur) > 0 {
+		batches = append(batches, cur)
+	}
+	return batches
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func str(s string) *string { return &s }
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		prefix      string
+		desired     Desired
+		actual      State
+		wantChanged bool
+		wantAdd     []string
+		wantRemove  []string
+		wantAlias   *string
+		wantMobile  *string
+	}{
+		{
+			name:    "unmanaged fields",
+			desired: Desired{RegistrationID: "r1"},
+			actual:  State{Tags: []string{"a"}, Alias: "x", Mobile: "1"},
+		},
+		{
+			name:    "already in sync",
+			desired: Desired{RegistrationID: "r1", Tags: []string{"b", "a"}, Alias: str("x"), Mobile: str("1")},
+			actual:  State{Tags: []string{"a", "b"}, Alias: "x", Mobile: "1"},
+		},
+		{
+			name:        "tags added and removed in sorted order",
+			desired:     Desired{RegistrationID: "r1", Tags: []string{"d", "c", "a"}},
+			actual:      State{Tags: []string{"f", "a", "e"}},
+			wantChanged: true,
+			wantAdd:     []string{"c", "d"},
+			wantRemove:  []string{"e", "f"},
+		},
+		{
+			name:        "empty tags remove all",
+			desired:     Desired{RegistrationID: "r1", Tags: []string{}},
+			actual:      State{Tags: []string{"b", "a"}},
+			wantChanged: true,
+			wantRemove:  []string{"a", "b"},
+		},
+		{
+			name:        "unmanaged tags kept with prefix",
+			prefix:      "m_",
+			desired:     Desired{RegistrationID: "r1", Tags: []string{"m_b"}},
+			actual:      State{Tags: []string{"m_a", "other", "m_b"}},
+			wantChanged: true,
+			wantRemove:  []string{"m_a"},
+		},
+		{
+			name:        "alias and mobile cleared",
+			desired:     Desired{RegistrationID: "r1", Alias: str(""), Mobile: str("")},
+			actual:      State{Alias: "x", Mobile: "1"},
+			wantChanged: true,
+			wantAlias:   str(""),
+			wantMobile:  str(""),
+		},
+		{
+			name:        "alias set",
+			desired:     Desired{RegistrationID: "r1", Alias: str("y"), Mobile: str("1")},
+			actual:      State{Alias: "x", Mobile: "1"},
+			wantChanged: true,
+			wantAlias:   str("y"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Reconciler{cfg: config{tagPrefix: tt.prefix}}
+			c, changed := r.diff(tt.desired, tt.actual)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if c.RegistrationID != tt.desired.RegistrationID || !reflect.DeepEqual(c.Actual, tt.actual) {
+				t.Errorf("got %s with actual %+v", c.RegistrationID, c.Actual)
+			}
+			if !reflect.DeepEqual(c.AddTags, tt.wantAdd) {
+				t.Errorf("AddTags = %v, want %v", c.AddTags, tt.wantAdd)
+			}
+			if !reflect.DeepEqual(c.RemoveTags, tt.wantRemove) {
+				t.Errorf("RemoveTags = %v, want %v", c.RemoveTags, tt.wantRemove)
+			}
+			if !reflect.DeepEqual(c.Alias, tt.wantAlias) {
+				t.Errorf("Alias = %v, want %v", c.Alias, tt.wantAlias)
+			}
+			if !reflect.DeepEqual(c.Mobile, tt.wantMobile) {
+				t.Errorf("Mobile = %v, want %v", c.Mobile, tt.wantMobile)
+			}
+		})
+	}
+}
+
+func TestChangeClearsTags(t *testing.T) {
+	tests := []struct {
+		name   string
+		change Change
+		want   bool
+	}{
+		{
+			name:   "no tag changes",
+			change: Change{Actual: State{Tags: []string{"a"}}},
+		},
+		{
+			name:   "remove all tags",
+			change: Change{RemoveTags: []string{"a", "b"}, Actual: State{Tags: []string{"a", "b"}}},
+			want:   true,
+		},
+		{
+			name:   "remove some tags",
+			change: Change{RemoveTags: []string{"a"}, Actual: State{Tags: []string{"a", "b"}}},
+		},
+		{
+			name:   "replace all tags",
+			change: Change{AddTags: []string{"c"}, RemoveTags: []string{"a", "b"}, Actual: State{Tags: []string{"a", "b"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.change.clearsTags(); got != tt.want {
+				t.Errorf("clearsTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchTags(t *testing.T) {
+	tags := func(n, size int) []string {
+		s := make([]string, n)
+		for i := range s {
+			s[i] = strings.Repeat("t", size)
+		}
+		return s
+	}
+	tests := []struct {
+		name  string
+		tags  []string
+		sizes []int // 各批次的标签数
+	}{
+		{name: "empty", tags: nil, sizes: nil},
+		{name: "single batch", tags: tags(3, 10), sizes: []int{3}},
+		{name: "split by count", tags: tags(250, 1), sizes: []int{100, 100, 50}},
+		{name: "split by bytes", tags: tags(25, 100), sizes: []int{10, 10, 5}},
+		{name: "exactly at byte limit", tags: tags(10, 100), sizes: []int{10}},
+		{name: "oversized tag alone", tags: []string{"a", strings.Repeat("t", 1200), "b"}, sizes: []int{1, 1, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			batches := batchTags(tt.tags)
+			var sizes []int
+			var flat []string
+			for _, b := range batches {
+				sizes = append(sizes, len(b))
+				flat = append(flat, b...)
+			}
+			if !reflect.DeepEqual(sizes, tt.sizes) {
+				t.Errorf("batch sizes = %v, want %v", sizes, tt.sizes)
+			}
+			if !reflect.DeepEqual(flat, tt.tags) {
+				t.Errorf("batches do not preserve tag order")
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"sync"
+	"time"
+)
+
+// # 解析结果缓存
+//
+// 缓存别名与设备的解析结果（JSON 编码），可替换为 Redis 等共享缓存，实现需要保证并发安全。
+type Cache interface {
+	// 获取缓存的值。
+	Get(key string) (value []byte, ok bool)
+	// 缓存值，缓存项在 `ttl` 后过期。
+	Set(key string, value []byte, ttl time.Duration)
+	// 删除缓存项，缓存项不存在时忽略。
+	Delete(key string)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 内存缓存
+//
+// 默认的解析结果缓存，过期的缓存项在访问或缓存项数量翻倍时清除。
+type MemoryCache struct {
+	mu        sync.Mutex
+	items     map[string]memoryCacheItem
+	nextSweep int
+}
+
+type memoryCacheItem struct {
+	value    []byte
+	expireAt time.Time
+}
+
+// 创建新的内存缓存。
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{items: make(map[string]memoryCacheItem), nextSweep: 1024}
+}
+
+func (c *MemoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(item.expireAt) {
+		delete(c.items, key)
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	if len(c.items) >= c.nextSweep {
+		for k, item := range c.items {
+			if now.After(item.expireAt) {
+				delete(c.items, k)
+			}
+		}
+		c.nextSweep = 2*len(c.items) + 1024
+	}
+	c.items[key] = memoryCacheItem{value: value, expireAt: now.Add(ttl)}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.items, key)
+}
+
+// 缓存项数量（包括尚未清除的过期缓存项）。
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
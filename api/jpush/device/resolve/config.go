@@ -0,0 +1,176 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultTTL         = 10 * time.Minute // 默认的缓存有效期
+	defaultKeyPrefix   = "jpush:resolve:" // 默认的缓存键前缀
+	defaultConcurrency = 4                // 默认的并发查询数
+	defaultRateLimit   = 10               // 默认每个限速周期内允许的查询请求数
+	defaultRatePer     = time.Second      // 默认的限速周期
+)
+
+// 默认解析别名时查询的平台。
+var defaultPlatforms = []platform.Platform{platform.Android, platform.IOS, platform.HMOS, platform.QuickApp}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 解析器配置。
+type config struct {
+	logger      jiguang.Logger      // 日志打印器，默认为 api.DefaultJPushLogger
+	cache       Cache               // 解析结果缓存，默认为 MemoryCache
+	ttl         time.Duration       // 缓存有效期，默认为 10 分钟
+	keyPrefix   string              // 缓存键前缀，默认为 "jpush:resolve:"
+	concurrency int                 // 批量解析时的并发查询数，默认为 4
+	limiter     *api.RateLimiter    // API 调用限速器，默认为每秒 10 次
+	platforms   []platform.Platform // 解析别名时查询的平台，默认为 android、ios、hmos、quickapp
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 解析器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置解析器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 缓存配置选项。
+type cacheOption struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+func (o cacheOption) apply(c *config) error {
+	if o.cache == nil {
+		return errors.New("`cache` cannot be nil")
+	}
+	if o.ttl <= 0 {
+		return errors.New("`ttl` must be positive")
+	}
+	c.cache = o.cache
+	c.ttl = o.ttl
+	return nil
+}
+
+// 自定义配置解析结果缓存及其有效期，默认为有效期 10 分钟的 MemoryCache。
+func WithCache(cache Cache, ttl time.Duration) ConfigOption {
+	return cacheOption{cache, ttl}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 缓存键前缀配置选项。
+type keyPrefixOption string
+
+func (o keyPrefixOption) apply(c *config) error {
+	c.keyPrefix = string(o)
+	return nil
+}
+
+// 自定义配置缓存键前缀，默认为 "jpush:resolve:"；多个应用共用同一个共享缓存时，应为每个应用配置不同的前缀（如包含 AppKey）。
+func WithKeyPrefix(prefix string) ConfigOption {
+	return keyPrefixOption(prefix)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发查询数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置批量解析时同时进行的查询请求数，默认为 4。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置查询请求使用的限速器（详见 api.RateLimiter），默认为每秒 10 次；命中缓存的解析不计入。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 查询平台配置选项。
+type platformsOption []platform.Platform
+
+func (o platformsOption) apply(c *config) error {
+	if len(o) == 0 {
+		return errors.New("`plats` cannot be empty")
+	}
+	for _, p := range o {
+		if p == "" || p == platform.All {
+			return errors.New("`plats` must be specific platforms")
+		}
+	}
+	c.platforms = o
+	return nil
+}
+
+// 自定义配置解析别名时逐个查询的平台，默认为 android、ios、hmos、quickapp。
+func WithPlatforms(plats ...platform.Platform) ConfigOption {
+	return platformsOption(plats)
+}
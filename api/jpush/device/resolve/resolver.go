@@ -0,0 +1,474 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+// # 设备的标签、别名与手机号码
+type Device struct {
+	RegistrationID string   `json:"registration_id"`  // 设备标识 Registration ID
+	Tags           []string `json:"tags,omitempty"`   // 设备标签
+	Alias          string   `json:"alias,omitempty"`  // 设备别名
+	Mobile         string   `json:"mobile,omitempty"` // 设备手机号码
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 用户与设备解析器
+//
+// 将用户（别名）解析为设备标识 Registration ID（通过 GetAlias 按平台逐个查询），或将 Registration ID 解析为别名与标签（通过 GetDevice），
+// 解析结果按 TTL 缓存，并发的相同查询只会发起一次请求。
+//
+// Resolver 同时实现了 device.APIv3，可替代原设备 API 使用：通过它调用 SetDevice、ClearDevice*、SetTag、DeleteTag、DeleteAlias、DeleteAliases 时，
+// 会自动使受影响的缓存项失效；其余方法直接透传。
+//
+// 失效时，本进程内进行中的查询结果不会再写入缓存；DeleteTag 无法确定标签下的设备，通过更新缓存中的设备缓存代数使所有设备缓存项失效，
+// 使用 Redis 等共享缓存时对其他进程同样有效。通过其他途径（如极光控制台）修改的绑定关系，以及其他进程中与失效并发的查询写回的旧值，只能等待缓存过期。
+type Resolver struct {
+	device.APIv3
+	cfg config
+
+	guard    sync.RWMutex // 写入缓存时持有读锁，失效时持有写锁，保证失效之后不会再写入失效前发起的查询结果
+	mu       sync.Mutex
+	epoch    uint64 // 失效次数，查询期间发生失效时，查询结果不写入缓存
+	inflight map[string]*call
+}
+
+// 进行中的查询。
+type call struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// 创建新的用户与设备解析器。
+//   - deviceAPI：【必填】设备 API v3 接口；
+//   - opts：【可选】解析器配置选项。
+func NewResolver(deviceAPI device.APIv3, opts ...ConfigOption) (*Resolver, error) {
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		ttl:         defaultTTL,
+		keyPrefix:   defaultKeyPrefix,
+		concurrency: defaultConcurrency,
+		platforms:   defaultPlatforms,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+	if c.cache == nil {
+		c.cache = NewMemoryCache()
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Resolver{APIv3: deviceAPI, cfg: c, inflight: make(map[string]*call)}, nil
+}
+
+// 获取别名绑定的设备（包括平台与最后上线日期）。
+func (r *Resolver) AliasDevices(ctx context.Context, alias string) ([]device.AliasGetData, error) {
+	if alias == "" {
+		return nil, errors.New("`alias` cannot be empty")
+	}
+	value, err := r.load(ctx, r.aliasKey(alias), func(epoch uint64) (interface{}, error) {
+		return r.fetchAlias(ctx, alias, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	var data []device.AliasGetData
+	if err = json.Unmarshal(value, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// 获取别名绑定的设备标识 Registration ID 列表。
+func (r *Resolver) RegistrationIDs(ctx context.Context, alias string) ([]string, error) {
+	data, err := r.AliasDevices(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	regIDs := make([]string, len(data))
+	for i, d := range data {
+		regIDs[i] = d.RegistrationID
+	}
+	return regIDs, nil
+}
+
+// 获取设备的标签、别名与手机号码。
+func (r *Resolver) Device(ctx context.Context, registrationID string) (*Device, error) {
+	if registrationID == "" {
+		return nil, errors.New("`registrationID` cannot be empty")
+	}
+	value, err := r.load(ctx, r.deviceKey(registrationID), func(epoch uint64) (interface{}, error) {
+		return r.fetchDevice(ctx, registrationID, epoch)
+	})
+	if err != nil {
+		return nil, err
+	}
+	d := &Device{}
+	if err = json.Unmarshal(value, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// 批量将别名解析为 Registration ID 列表（重复及空的别名会被忽略），返回解析成功的结果和解析失败的错误，key 均为别名。
+func (r *Resolver) ResolveAliases(ctx context.Context, aliases []string) (map[string][]string, map[string]error) {
+	aliases = api.DedupeStrings(aliases)
+	regIDs := make([][]string, len(aliases))
+	errs := make([]error, len(aliases))
+	api.RunConcurrently(len(aliases), r.cfg.concurrency, func(i int) {
+		regIDs[i], errs[i] = r.RegistrationIDs(ctx, aliases[i])
+	})
+
+	resolved, failed := make(map[string][]string, len(aliases)), make(map[string]error)
+	for i, alias := range aliases {
+		if errs[i] != nil {
+			failed[alias] = errs[i]
+		} else {
+			resolved[alias] = regIDs[i]
+		}
+	}
+	return resolved, failed
+}
+
+// 批量获取设备的标签、别名与手机号码（重复及空的 Registration ID 会被忽略），返回获取成功的结果和获取失败的错误，key 均为 Registration ID。
+func (r *Resolver) ResolveDevices(ctx context.Context, registrationIDs []string) (map[string]*Device, map[string]error) {
+	registrationIDs = api.DedupeStrings(registrationIDs)
+	devices := make([]*Device, len(registrationIDs))
+	errs := make([]error, len(registrationIDs))
+	api.RunConcurrently(len(registrationIDs), r.cfg.concurrency, func(i int) {
+		devices[i], errs[i] = r.Device(ctx, registrationIDs[i])
+	})
+
+	resolved, failed := make(map[string]*Device, len(registrationIDs)), make(map[string]error)
+	for i, regID := range registrationIDs {
+		if errs[i] != nil {
+			failed[regID] = errs[i]
+		} else {
+			resolved[regID] = devices[i]
+		}
+	}
+	return resolved, failed
+}
+
+// 使别名的缓存项失效。
+func (r *Resolver) InvalidateAlias(alias string) {
+	r.bump()
+	if value, ok := r.cfg.cache.Get(r.aliasKey(alias)); ok {
+		var data []device.AliasGetData
+		if json.Unmarshal(value, &data) == nil {
+			for _, d := range data {
+				r.cfg.cache.Delete(r.aliasOfKey(d.RegistrationID))
+			}
+		}
+	}
+	r.cfg.cache.Delete(r.aliasKey(alias))
+}
+
+// 使设备的缓存项失效；`aliasChanged` 为 true 时，设备原别名的缓存项也会失效。
+func (r *Resolver) InvalidateDevice(registrationID string, aliasChanged bool) {
+	r.bump()
+	if aliasChanged {
+		if old, ok := r.cfg.cache.Get(r.aliasOfKey(registrationID)); ok {
+			r.cfg.cache.Delete(r.aliasKey(string(old)))
+		}
+		r.cfg.cache.Delete(r.aliasOfKey(registrationID))
+	}
+	r.cfg.cache.Delete(r.deviceKey(registrationID))
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 设置设备的标签、别名与手机号码，并使设备（以及设置别名时的原别名与新别名）的缓存项失效。
+func (r *Resolver) SetDevice(ctx context.Context, registrationID string, param *device.DeviceSetParam) (*device.DeviceSetResult, error) {
+	aliasChanged := param != nil && param.Alias != nil
+	defer func() {
+		r.InvalidateDevice(registrationID, aliasChanged)
+		if aliasChanged && *param.Alias != "" {
+			r.InvalidateAlias(*param.Alias)
+		}
+	}()
+	return r.APIv3.SetDevice(ctx, registrationID, param)
+}
+
+// 清空设备的标签，并使设备的缓存项失效。
+func (r *Resolver) ClearDeviceTags(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, false)
+	return r.APIv3.ClearDeviceTags(ctx, registrationID)
+}
+
+// 清空设备的别名，并使设备及其原别名的缓存项失效。
+func (r *Resolver) ClearDeviceAlias(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, true)
+	return r.APIv3.ClearDeviceAlias(ctx, registrationID)
+}
+
+// 清空设备的手机号码，并使设备的缓存项失效。
+func (r *Resolver) ClearDeviceMobile(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, false)
+	return r.APIv3.ClearDeviceMobile(ctx, registrationID)
+}
+
+// 清空设备的标签与别名，并使设备及其原别名的缓存项失效。
+func (r *Resolver) ClearDeviceTagsAndAlias(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, true)
+	return r.APIv3.ClearDeviceTagsAndAlias(ctx, registrationID)
+}
+
+// 清空设备的标签与手机号码，并使设备的缓存项失效。
+func (r *Resolver) ClearDeviceTagsAndMobile(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, false)
+	return r.APIv3.ClearDeviceTagsAndMobile(ctx, registrationID)
+}
+
+// 清空设备的别名与手机号码，并使设备及其原别名的缓存项失效。
+func (r *Resolver) ClearDeviceAliasAndMobile(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, true)
+	return r.APIv3.ClearDeviceAliasAndMobile(ctx, registrationID)
+}
+
+// 清空设备的标签、别名与手机号码，并使设备及其原别名的缓存项失效。
+func (r *Resolver) ClearDeviceAll(ctx context.Context, registrationID string) (*device.DeviceClearResult, error) {
+	defer r.InvalidateDevice(registrationID, true)
+	return r.APIv3.ClearDeviceAll(ctx, registrationID)
+}
+
+// 更新标签，并使涉及设备的缓存项失效。
+func (r *Resolver) SetTag(ctx context.Context, tag string, adds, removes []string) (*device.TagSetResult, error) {
+	defer func() {
+		for _, regID := range adds {
+			r.InvalidateDevice(regID, false)
+		}
+		for _, regID := range removes {
+			r.InvalidateDevice(regID, false)
+		}
+	}()
+	return r.APIv3.SetTag(ctx, tag, adds, removes)
+}
+
+// 删除标签，并使所有设备的缓存项失效（无法确定标签下的设备）。
+//
+// 设备缓存代数记录在缓存中，有效期与缓存项相同：代数过期时，在其之前写入的设备缓存项也已全部过期。
+func (r *Resolver) DeleteTag(ctx context.Context, tag string, plats ...platform.Platform) (*device.TagDeleteResult, error) {
+	defer func() {
+		r.bump()
+		r.cfg.cache.Set(r.generationKey(), []byte(strconv.FormatInt(time.Now().UnixNano(), 36)), r.cfg.ttl)
+	}()
+	return r.APIv3.DeleteTag(ctx, tag, plats...)
+}
+
+// 删除别名，并使别名及其已缓存设备的缓存项失效。
+func (r *Resolver) DeleteAlias(ctx context.Context, alias string, plats ...platform.Platform) (*device.AliasDeleteResult, error) {
+	defer func() {
+		r.bump()
+		if value, ok := r.cfg.cache.Get(r.aliasKey(alias)); ok {
+			var data []device.AliasGetData
+			if json.Unmarshal(value, &data) == nil {
+				for _, d := range data {
+					r.cfg.cache.Delete(r.deviceKey(d.RegistrationID))
+				}
+			}
+		}
+		r.InvalidateAlias(alias)
+	}()
+	return r.APIv3.DeleteAlias(ctx, alias, plats...)
+}
+
+// 解绑设备与别名的关系，并使别名及涉及设备的缓存项失效。
+func (r *Resolver) DeleteAliases(ctx context.Context, alias string, registrationIDs []string) (*device.AliasesDeleteResult, error) {
+	defer func() {
+		r.InvalidateAlias(alias)
+		for _, regID := range registrationIDs {
+			r.InvalidateDevice(regID, true)
+		}
+	}()
+	return r.APIv3.DeleteAliases(ctx, alias, registrationIDs)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 优先从缓存获取，否则调用 `fetch` 查询并缓存；并发的相同查询共享同一次请求。
+//
+// 共享的请求因发起查询的调用方 ctx 取消或超时而失败时，ctx 仍然有效的等待方会重新查询，而不是返回该错误。
+func (r *Resolver) load(ctx context.Context, key string, fetch func(epoch uint64) (interface{}, error)) ([]byte, error) {
+	for {
+		if value, ok := r.cfg.cache.Get(key); ok {
+			return value, nil
+		}
+
+		r.mu.Lock()
+		c, ok := r.inflight[key]
+		if !ok {
+			c = &call{done: make(chan struct{})}
+			r.inflight[key] = c
+			epoch := r.epoch
+			r.mu.Unlock()
+			return r.do(key, c, epoch, fetch)
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-c.done:
+			if isContextErr(c.err) && ctx.Err() == nil {
+				continue
+			}
+			return c.value, c.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// 执行查询 `c` 并写入缓存，完成后唤醒等待方。
+func (r *Resolver) do(key string, c *call, epoch uint64, fetch func(epoch uint64) (interface{}, error)) ([]byte, error) {
+	defer func() {
+		r.mu.Lock()
+		if r.inflight[key] == c {
+			delete(r.inflight, key)
+		}
+		r.mu.Unlock()
+		close(c.done)
+	}()
+
+	v, err := fetch(epoch)
+	if err != nil {
+		c.err = err
+		return nil, err
+	}
+	if c.value, c.err = json.Marshal(v); c.err != nil {
+		return nil, c.err
+	}
+	r.store(epoch, key, c.value)
+	return c.value, nil
+}
+
+// 查询期间（自 `epoch` 起）未发生失效时写入缓存，避免用失效前发起的查询结果覆盖失效。
+func (r *Resolver) store(epoch uint64, key string, value []byte) {
+	r.guard.RLock()
+	defer r.guard.RUnlock()
+	r.mu.Lock()
+	current := r.epoch
+	r.mu.Unlock()
+	if current == epoch {
+		r.cfg.cache.Set(key, value, r.cfg.ttl)
+	}
+}
+
+// 开始失效：使进行中的查询结果不再写入缓存，并使后续查询不再共享进行中的请求。
+func (r *Resolver) bump() {
+	r.guard.Lock()
+	defer r.guard.Unlock()
+	r.mu.Lock()
+	r.epoch++
+	r.inflight = make(map[string]*call)
+	r.mu.Unlock()
+}
+
+func (r *Resolver) fetchAlias(ctx context.Context, alias string, epoch uint64) ([]device.AliasGetData, error) {
+	var (
+		data = make([]device.AliasGetData, 0)
+		seen = make(map[string]struct{})
+	)
+	for _, p := range r.cfg.platforms {
+		if err := r.cfg.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+		result, err := r.APIv3.GetAlias(ctx, alias, p)
+		if err != nil {
+			return nil, err
+		}
+		r.cfg.limiter.Observe(result.Rate)
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, fmt.Errorf("get alias %q on %s: %w", alias, p, err)
+		}
+		for _, d := range result.Data {
+			if _, ok := seen[d.RegistrationID]; ok {
+				continue
+			}
+			seen[d.RegistrationID] = struct{}{}
+			if d.Platform == "" {
+				d.Platform = p
+			}
+			data = append(data, d)
+			r.store(epoch, r.aliasOfKey(d.RegistrationID), []byte(alias))
+		}
+	}
+	return data, nil
+}
+
+func (r *Resolver) fetchDevice(ctx context.Context, regID string, epoch uint64) (*Device, error) {
+	if err := r.cfg.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	result, err := r.APIv3.GetDevice(ctx, regID)
+	if err != nil {
+		return nil, err
+	}
+	r.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	if result.Alias != "" {
+		r.store(epoch, r.aliasOfKey(regID), []byte(result.Alias))
+	}
+	return &Device{RegistrationID: regID, Tags: result.Tags, Alias: result.Alias, Mobile: result.Mobile}, nil
+}
+
+func isContextErr(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+func (r *Resolver) aliasKey(alias string) string {
+	return r.cfg.keyPrefix + "alias:" + alias
+}
+
+func (r *Resolver) aliasOfKey(regID string) string {
+	return r.cfg.keyPrefix + "alias-of:" + regID
+}
+
+// 设备缓存键包含缓存中记录的设备缓存代数（尚未执行过 DeleteTag 时为空）。
+func (r *Resolver) deviceKey(regID string) string {
+	generation, _ := r.cfg.cache.Get(r.generationKey())
+	return r.cfg.keyPrefix + "device:" + string(generation) + ":" + regID
+}
+
+func (r *Resolver) generationKey() string {
+	return r.cfg.keyPrefix + "generation"
+}
@@ -0,0 +1,285 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resolve
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+)
+
+// 内存中的设备 API，记录查询次数。
+type fakeDevice struct {
+	device.APIv3
+
+	mu          sync.Mutex
+	aliases     map[string]string // Registration ID -> 别名
+	aliasCalls  int
+	deviceCalls int
+	hold        chan struct{} // 不为 nil 时，下一次 GetDevice 在其关闭或 ctx 取消后才返回
+	started     chan struct{} // GetDevice 开始等待 hold 时关闭
+}
+
+func newFakeDevice(aliases map[string]string) *fakeDevice {
+	return &fakeDevice{aliases: aliases}
+}
+
+func ok() *api.Response { return &api.Response{StatusCode: 200} }
+
+func (f *fakeDevice) GetAlias(_ context.Context, alias string, _ ...platform.Platform) (*device.AliasGetResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.aliasCalls++
+	result := &device.AliasGetResult{Response: ok()}
+	for regID, a := range f.aliases {
+		if a == alias {
+			result.Data = append(result.Data, device.AliasGetData{RegistrationID: regID})
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeDevice) GetDevice(ctx context.Context, regID string) (*device.DeviceGetResult, error) {
+	f.mu.Lock()
+	f.deviceCalls++
+	hold, started := f.hold, f.started
+	f.hold, f.started = nil, nil
+	alias := f.aliases[regID]
+	f.mu.Unlock()
+
+	if hold != nil {
+		close(started)
+		select {
+		case <-hold:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	return &device.DeviceGetResult{Response: ok(), Alias: alias}, nil
+}
+
+func (f *fakeDevice) SetDevice(_ context.Context, regID string, param *device.DeviceSetParam) (*device.DeviceSetResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if param.Alias != nil {
+		f.aliases[regID] = *param.Alias
+	}
+	return &device.DeviceSetResult{Response: ok()}, nil
+}
+
+func (f *fakeDevice) DeleteTag(context.Context, string, ...platform.Platform) (*device.TagDeleteResult, error) {
+	return &device.TagDeleteResult{Response: ok()}, nil
+}
+
+func (f *fakeDevice) DeleteAlias(_ context.Context, alias string, _ ...platform.Platform) (*device.AliasDeleteResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for regID, a := range f.aliases {
+		if a == alias {
+			delete(f.aliases, regID)
+		}
+	}
+	return &device.AliasDeleteResult{Response: ok()}, nil
+}
+
+func (f *fakeDevice) calls() (alias, dev int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.aliasCalls, f.deviceCalls
+}
+
+// 阻塞下一次 GetDevice，返回其开始等待的信号和放行函数。
+func (f *fakeDevice) block() (started <-chan struct{}, release func()) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.hold, f.started = make(chan struct{}), make(chan struct{})
+	hold := f.hold
+	return f.started, func() { close(hold) }
+}
+
+func newTestResolver(t *testing.T, f *fakeDevice) *Resolver {
+	t.Helper()
+	limiter, err := api.NewRateLimiter(1000, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewResolver(f, WithPlatforms(platform.Android), WithRateLimiter(limiter))
+	if err != nil {
+		t.Fatalf("new resolver: %v", err)
+	}
+	return r
+}
+
+func TestStaleWriteSuppressedAfterInvalidation(t *testing.T) {
+	f := newFakeDevice(map[string]string{"r1": "u1"})
+	r := newTestResolver(t, f)
+	started, release := f.block()
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := r.Device(context.Background(), "r1")
+		done <- err
+	}()
+	<-started
+	r.InvalidateDevice("r1", true) // 查询进行中发生失效
+	release()
+	if err := <-done; err != nil {
+		t.Fatalf("device: %v", err)
+	}
+
+	if _, ok := r.cfg.cache.Get(r.deviceKey("r1")); ok {
+		t.Error("result of a lookup started before invalidation was cached")
+	}
+	if _, ok := r.cfg.cache.Get(r.aliasOfKey("r1")); ok {
+		t.Error("alias-of of a lookup started before invalidation was cached")
+	}
+	if _, err := r.Device(context.Background(), "r1"); err != nil {
+		t.Fatalf("device: %v", err)
+	}
+	if _, n := f.calls(); n != 2 {
+		t.Errorf("GetDevice called %d times, want 2", n)
+	}
+	if _, ok := r.cfg.cache.Get(r.deviceKey("r1")); !ok {
+		t.Error("result of a lookup after invalidation was not cached")
+	}
+}
+
+func TestWaiterRetriesAfterLeaderCancelled(t *testing.T) {
+	f := newFakeDevice(map[string]string{"r1": "u1"})
+	r := newTestResolver(t, f)
+	started, _ := f.block()
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error, 1)
+	go func() {
+		_, err := r.Device(leaderCtx, "r1")
+		leader <- err
+	}()
+	<-started
+
+	waiter := make(chan error, 1)
+	go func() {
+		d, err := r.Device(context.Background(), "r1")
+		if err == nil && d.Alias != "u1" {
+			err = errors.New("unexpected alias " + d.Alias)
+		}
+		waiter <- err
+	}()
+	time.Sleep(20 * time.Millisecond) // 等待方加入进行中的查询
+	cancel()
+
+	if err := <-leader; !errors.Is(err, context.Canceled) {
+		t.Errorf("leader err = %v, want %v", err, context.Canceled)
+	}
+	if err := <-waiter; err != nil {
+		t.Errorf("waiter err = %v, want nil", err)
+	}
+}
+
+func TestDeleteTagInvalidatesDevices(t *testing.T) {
+	f := newFakeDevice(map[string]string{"r1": "u1", "r2": ""})
+	r := newTestResolver(t, f)
+	ctx := context.Background()
+
+	for _, regID := range []string{"r1", "r2", "r1", "r2"} {
+		if _, err := r.Device(ctx, regID); err != nil {
+			t.Fatalf("device %s: %v", regID, err)
+		}
+	}
+	if _, n := f.calls(); n != 2 {
+		t.Fatalf("GetDevice called %d times before DeleteTag, want 2", n)
+	}
+
+	if _, err := r.DeleteTag(ctx, "vip"); err != nil {
+		t.Fatalf("delete tag: %v", err)
+	}
+	for _, regID := range []string{"r1", "r2"} {
+		if _, err := r.Device(ctx, regID); err != nil {
+			t.Fatalf("device %s: %v", regID, err)
+		}
+	}
+	if _, n := f.calls(); n != 4 {
+		t.Errorf("GetDevice called %d times after DeleteTag, want 4", n)
+	}
+}
+
+func TestAliasOfCleanup(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(ctx context.Context, r *Resolver) error
+		want   map[string][]string // 变更后重新解析的结果
+	}{
+		{
+			name: "set device alias",
+			mutate: func(ctx context.Context, r *Resolver) error {
+				alias := "u2"
+				_, err := r.SetDevice(ctx, "r1", &device.DeviceSetParam{Alias: &alias})
+				return err
+			},
+			want: map[string][]string{"u1": {"r2"}, "u2": {"r1"}},
+		},
+		{
+			name: "delete alias",
+			mutate: func(ctx context.Context, r *Resolver) error {
+				_, err := r.DeleteAlias(ctx, "u1")
+				return err
+			},
+			want: map[string][]string{"u1": {}, "u2": {}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFakeDevice(map[string]string{"r1": "u1", "r2": "u1"})
+			r := newTestResolver(t, f)
+			ctx := context.Background()
+
+			if _, err := r.RegistrationIDs(ctx, "u1"); err != nil {
+				t.Fatalf("resolve: %v", err)
+			}
+			for _, regID := range []string{"r1", "r2"} {
+				if _, ok := r.cfg.cache.Get(r.aliasOfKey(regID)); !ok {
+					t.Fatalf("alias-of %s was not cached", regID)
+				}
+			}
+
+			if err := tt.mutate(ctx, r); err != nil {
+				t.Fatalf("mutate: %v", err)
+			}
+			if _, ok := r.cfg.cache.Get(r.aliasOfKey("r1")); ok {
+				t.Error("alias-of r1 was not invalidated")
+			}
+			if _, ok := r.cfg.cache.Get(r.aliasKey("u1")); ok {
+				t.Error("alias u1 was not invalidated")
+			}
+
+			for alias, want := range tt.want {
+				got, err := r.RegistrationIDs(ctx, alias)
+				if err != nil {
+					t.Fatalf("resolve %s: %v", alias, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("resolve %s = %v, want %v", alias, got, want)
+				}
+			}
+		})
+	}
+}
@@ -33,6 +33,8 @@ type APIv3Builder struct {
 	devSecret         string
 	logger            jiguang.Logger
 	httpLogLevel      api.HttpLogLevel
+	sm2               bool
+	sm2Keys           jiguang.SM2KeyProvider
 	err               error
 }
 
@@ -124,6 +126,27 @@ func (b *APIv3Builder) DisableHttpLogging() *APIv3Builder {
 	return b
 }
 
+// 【可选】启用 SM2 加密推送，默认不启用。
+//
+// 启用后，Send、SendByFile 及其对应的 Custom* 接口的请求正文都会使用 SM2 公钥加密，并携带请求头 `X-Encrypt-Type: SM2`。
+func (b *APIv3Builder) EnableSM2Encryption() *APIv3Builder {
+	b.sm2 = true
+	return b
+}
+
+// 【可选】设置 SM2 加密推送使用的公钥提供者，默认为 jiguang.DefaultSM2KeyProvider（极光推送服务端的 SM2 公钥）。
+//
+// 可通过 jiguang.NewSM2Key 解析 PEM、DER 或 Base64 编码的公钥点格式的公钥，并通过 jiguang.NewSM2KeyRing 组合多个同时有效的公钥，
+// 以便在公钥轮换期间平滑切换；公钥标识非空时，会通过请求头 `X-Encrypt-Key-Id` 告知服务端。
+func (b *APIv3Builder) SetSM2KeyProvider(keys jiguang.SM2KeyProvider) *APIv3Builder {
+	if keys == nil {
+		b.err = errors.New("`keys` cannot be nil")
+		return b
+	}
+	b.sm2Keys = keys
+	return b
+}
+
 func (b *APIv3Builder) Build() (APIv3, error) {
 	if b.err != nil {
 		return (*apiv3)(nil), b.err
@@ -151,14 +174,18 @@ func (b *APIv3Builder) Build() (APIv3, error) {
 		proto:     proto,
 		host:      b.host,
 		auth:      "Basic " + creds,
+		sm2:       b.sm2,
+		sm2Keys:   b.sm2Keys,
 	}, nil
 }
 
 // apiv3 内部实现了 APIv3，是 Group Push API v3 的默认访问客户端。
 type apiv3 struct {
 	fileAPIv3
-	client api.HttpClient
-	proto  string
-	host   string
-	auth   string
+	client  api.HttpClient
+	proto   string
+	host    string
+	auth    string
+	sm2     bool                   // 是否启用 SM2 加密推送
+	sm2Keys jiguang.SM2KeyProvider // SM2 加密推送使用的公钥提供者，为 nil 时使用默认公钥
 }
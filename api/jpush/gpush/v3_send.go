@@ -57,6 +57,11 @@ func (gp *apiv3) CustomSend(ctx context.Context, param interface{}) (*SendResult
 		Auth:   gp.auth,
 		Body:   param,
 	}
+	if gp.sm2 {
+		if err := req.EncryptWithSM2(gp.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := gp.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
@@ -59,6 +59,14 @@ func (gr *apiv3) GetUserDetail(ctx context.Context, start jiguang.UnitTime, dura
 		}
 	}
 
+	if tu == jiguang.TimeUnitHour {
+		loc := gr.loc
+		if loc == nil {
+			loc = jiguang.APILocation()
+		}
+		start.Time = start.In(loc)
+	}
+
 	query := "?time_unit=" + tu.String() + "&start=" + url.QueryEscape(start.Format()) + "&duration=" + strconv.Itoa(duration)
 	req := &api.Request{
 		Method: http.MethodGet,
@@ -0,0 +1,245 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callback
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultAddr = ":8090"     // 默认监听地址
+	defaultPath = "/callback" // 默认回调路径
+)
+
+// 标志位：是否已经设置了自定义的回执数据处理器
+const (
+	flagReceived int8 = 1 << iota
+	flagClicked
+	flagNotReceived
+	flagPush
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 回调接口服务配置。
+type config struct {
+	addr        string            // 监听地址 (如 ":8090")，默认为 ":8090"
+	path        string            // 回调路径 (如 "/callback")，默认为 "/callback"
+	logger      jiguang.Logger    // 日志打印器，用于记录回调接口服务的日志，默认为 api.DefaultJPushLogger
+	checkAuth   bool              // 是否开启安全校验，默认开启
+	handler     http.Handler      // HTTP Handler，可自定义处理回调请求，默认为使用 net/http 实现的一个简单的 Handler
+	flag        int8              // 标志位，用于标记是否已经设置了自定义的回执数据处理器，从低位到高位分别表示：Received、Clicked、NotReceived、Push
+	received    DataProcessor     // 送达 (1) 回执数据处理器，为 nil 时不处理
+	clicked     DataProcessor     // 点击 (2) 回执数据处理器，为 nil 时不处理
+	notReceived DataProcessor     // 未送达 (4) 回执数据处理器，为 nil 时不处理
+	push        DataProcessor     // 推送成功 (8) 回执数据处理器，为 nil 时不处理
+	unified     DataListProcessor // 统一的回执数据列表处理器，为 nil 时不处理
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 回调接口服务配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 监听地址配置选项。
+type addrOption string
+
+func (o addrOption) apply(c *config) error {
+	addr := string(o)
+	if addr == "" {
+		return errors.New("`addr` cannot be empty")
+	}
+	c.addr = addr
+	return nil
+}
+
+// 自定义配置回调接口服务监听地址，默认为 ":8090"。
+func WithAddr(addr string) ConfigOption {
+	return addrOption(addr)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 回调路径配置选项。
+type pathOption string
+
+func (o pathOption) apply(c *config) error {
+	path := string(o)
+	if path == "" {
+		return errors.New("`path` cannot be empty")
+	}
+	c.path = path
+	return nil
+}
+
+// 自定义配置回调接口服务回调路径，默认为 "/callback"。
+func WithPath(path string) ConfigOption {
+	return pathOption(path)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置回调接口服务的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 是否开启安全校验配置选项。
+type checkAuthOption bool
+
+func (o checkAuthOption) apply(c *config) error {
+	c.checkAuth = bool(o)
+	return nil
+}
+
+// 自定义配置回调接口服务是否开启安全校验，默认开启。
+//
+// 开启后，回调请求需携带 appKey 和 masterSecret 的 HTTP Basic 认证信息，且回执数据中的 appkey（如有）需与 appKey 一致。
+func WithCheckAuth(checkAuth bool) ConfigOption {
+	return checkAuthOption(checkAuth)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// HTTP Handler 配置选项。
+type httpHandlerOption struct {
+	handler http.Handler
+}
+
+func (o httpHandlerOption) apply(c *config) error {
+	if o.handler == nil {
+		return errors.New("HTTP `handler` cannot be nil")
+	}
+	c.handler = o.handler
+	return nil
+}
+
+// 自定义配置回调接口服务的 HTTP Handler，默认为使用 net/http 实现的一个简单的 Handler。
+func WithHttpHandler(handler http.Handler) ConfigOption {
+	return httpHandlerOption{handler}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 送达 (1) 回执数据处理器配置选项。
+type receivedDataProcessorOption struct {
+	processor DataProcessor
+}
+
+func (o receivedDataProcessorOption) apply(c *config) error {
+	c.received = o.processor
+	c.flag |= flagReceived
+	return nil
+}
+
+// 自定义配置 送达 (1) 回执数据处理器。注：你的自定义处理器需要实现 DataProcessor 接口。
+func WithReceivedDataProcessor(processor DataProcessor) ConfigOption {
+	return receivedDataProcessorOption{processor}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 点击 (2) 回执数据处理器配置选项。
+type clickedDataProcessorOption struct {
+	processor DataProcessor
+}
+
+func (o clickedDataProcessorOption) apply(c *config) error {
+	c.clicked = o.processor
+	c.flag |= flagClicked
+	return nil
+}
+
+// 自定义配置 点击 (2) 回执数据处理器。注：你的自定义处理器需要实现 DataProcessor 接口。
+func WithClickedDataProcessor(processor DataProcessor) ConfigOption {
+	return clickedDataProcessorOption{processor}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 未送达 (4) 回执数据处理器配置选项。
+type notReceivedDataProcessorOption struct {
+	processor DataProcessor
+}
+
+func (o notReceivedDataProcessorOption) apply(c *config) error {
+	c.notReceived = o.processor
+	c.flag |= flagNotReceived
+	return nil
+}
+
+// 自定义配置 未送达 (4) 回执数据处理器。注：你的自定义处理器需要实现 DataProcessor 接口。
+func WithNotReceivedDataProcessor(processor DataProcessor) ConfigOption {
+	return notReceivedDataProcessorOption{processor}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 推送成功 (8) 回执数据处理器配置选项。
+type pushDataProcessorOption struct {
+	processor DataProcessor
+}
+
+func (o pushDataProcessorOption) apply(c *config) error {
+	c.push = o.processor
+	c.flag |= flagPush
+	return nil
+}
+
+// 自定义配置 推送成功 (8) 回执数据处理器。注：你的自定义处理器需要实现 DataProcessor 接口。
+func WithPushDataProcessor(processor DataProcessor) ConfigOption {
+	return pushDataProcessorOption{processor}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 统一的回执数据列表处理器配置选项（所有类型的回执数据汇总到此处理器中进行统一处理）。
+type dataListProcessorOption struct {
+	processor DataListProcessor
+}
+
+func (o dataListProcessorOption) apply(c *config) error {
+	c.unified = o.processor
+	c.flag = 0
+	return nil
+}
+
+// 统一的回执数据列表处理器（所有类型的回执数据汇总到此处理器中进行统一处理）。注：你的自定义处理器需要实现 DataListProcessor 接口。
+//   - 如果你不希望根据不同的回执数据类型使用不同的处理器，可以仅配置使用此选项。
+func WithDataListProcessor(processor DataListProcessor) ConfigOption {
+	return dataListProcessorOption{processor}
+}
@@ -0,0 +1,56 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callback
+
+import (
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// # 回执数据列表
+type DataList struct {
+	RawJSON string `json:"-"`     // 原始 JSON 数据
+	Items   []Data `json:"items"` // 回执数据项列表
+}
+
+// # 回执数据项
+type Data struct {
+	Type           Type                   `json:"type"`                      // 回执数据类型，取值为 Received、Clicked、NotReceived、Push 之一
+	AppKey         string                 `json:"appkey,omitempty"`          // 该条回执所对应的应用 AppKey
+	MsgID          string                 `json:"msgid,omitempty"`           // 该条回执所对应的推送消息 ID
+	RegistrationID string                 `json:"registration_id,omitempty"` // 该条回执所对应的设备注册 ID
+	Platform       string                 `json:"platform,omitempty"`        // 该条回执所对应的设备平台，如 "android"、"ios"、"hmos"
+	Channel        string                 `json:"channel,omitempty"`         // 该条回执所对应的下发通道，如 "jpush"、"xiaomi"、"huawei"、"apns" 等
+	Time           *jiguang.Timestamp     `json:"time,omitempty"`            // 产生该回执的时间点
+	Error          *api.CodeError         `json:"error,omitempty"`           // 未送达的原因，当 Type = NotReceived 时有值
+	Params         map[string]interface{} `json:"params,omitempty"`          // 自定义参数，即推送时在 Callback.Params 里自行指定的参数
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (t Type) String() string {
+	switch t {
+	case Received:
+		return "送达"
+	case Clicked:
+		return "点击"
+	case NotReceived:
+		return "未送达"
+	case Push:
+		return "推送成功"
+	default:
+		return "未知"
+	}
+}
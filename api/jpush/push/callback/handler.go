@@ -0,0 +1,144 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callback
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// 默认回调请求处理器。
+type defaultHandler struct {
+	appKey       string
+	masterSecret string
+	checkAuth    bool
+	received     DataProcessor
+	clicked      DataProcessor
+	notReceived  DataProcessor
+	push         DataProcessor
+	unified      DataListProcessor
+}
+
+func (h defaultHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	if h.checkAuth {
+		appKey, masterSecret, ok := r.BasicAuth()
+		if !ok {
+			http.Error(w, "invalid auth channel", http.StatusUnauthorized)
+			return
+		}
+		if appKey != h.appKey {
+			http.Error(w, "app key mismatch", http.StatusForbidden)
+			return
+		}
+		if masterSecret != h.masterSecret {
+			http.Error(w, "master secret mismatch", http.StatusForbidden)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		echostr := r.URL.Query().Get("echostr")
+		if echostr == "" {
+			http.Error(w, "missing 'echostr' parameter", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(echostr))
+		if err != nil {
+			http.Error(w, "failed to write response", http.StatusInternalServerError)
+			return
+		}
+	case http.MethodPost:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		defer func() { _ = r.Body.Close() }()
+
+		list := DataList{RawJSON: string(body)}
+		if err = unmarshalItems(body, &list.Items); err != nil {
+			http.Error(w, "invalid callback data", http.StatusBadRequest)
+			return
+		}
+
+		if h.checkAuth {
+			for _, data := range list.Items {
+				if data.AppKey != "" && data.AppKey != h.appKey {
+					http.Error(w, "app key mismatch", http.StatusForbidden)
+					return
+				}
+			}
+		}
+
+		if h.unified != nil {
+			h.unified.Process(list)
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+
+		var wg sync.WaitGroup
+		for _, data := range list.Items {
+			wg.Add(1)
+			go h.process(data, &wg)
+		}
+		wg.Wait()
+
+		w.WriteHeader(http.StatusOK)
+	default:
+		http.Error(w, "request method '"+r.Method+"' not supported", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h defaultHandler) process(data Data, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	switch data.Type {
+	case Received: // 送达 (1)
+		if h.received != nil {
+			h.received.Process(data)
+		}
+	case Clicked: // 点击 (2)
+		if h.clicked != nil {
+			h.clicked.Process(data)
+		}
+	case NotReceived: // 未送达 (4)
+		if h.notReceived != nil {
+			h.notReceived.Process(data)
+		}
+	case Push: // 推送成功 (8)
+		if h.push != nil {
+			h.push.Process(data)
+		}
+	}
+}
+
+// 回执数据可能是数据项列表，也可能是单个数据项。
+func unmarshalItems(body []byte, items *[]Data) error {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		var data Data
+		if err := json.Unmarshal(body, &data); err != nil {
+			return err
+		}
+		*items = []Data{data}
+		return nil
+	}
+	return json.Unmarshal(body, items)
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 回调接口服务核心结构。
+type Server struct {
+	server    *http.Server
+	path      string
+	isRunning bool
+	mu        sync.RWMutex
+	logger    jiguang.Logger
+}
+
+// 创建新的 Server 回调接口服务实例。
+//
+// 接收推送时通过 Callback 参数（或极光后台）配置的回执数据，包括：送达、点击、未送达、推送成功 4 种类型，
+// 并按回执数据类型分发给对应的回执数据处理器，或统一交由 WithDataListProcessor 配置的回执数据列表处理器处理。
+func NewServer(appKey, masterSecret string, opts ...ConfigOption) (*Server, error) {
+	c := config{
+		addr:      defaultAddr,
+		path:      defaultPath,
+		logger:    api.DefaultJPushLogger,
+		checkAuth: true,
+	}
+
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.flag > 0 {
+		c.unified = nil
+	} else {
+		if c.unified == nil {
+			c.unified = loggingDataListProcessor{
+				logger: c.logger, // 需要使用用户可能自定义设置的 logger
+			}
+		}
+	}
+
+	p := loggingDataProcessor{
+		logger: c.logger, // 需要使用用户可能自定义设置的 logger
+	}
+	if c.flag&flagReceived == 0 { // 送达 (1)
+		c.received = p
+	}
+	if c.flag&flagClicked == 0 { // 点击 (2)
+		c.clicked = p
+	}
+	if c.flag&flagNotReceived == 0 { // 未送达 (4)
+		c.notReceived = p
+	}
+	if c.flag&flagPush == 0 { // 推送成功 (8)
+		c.push = p
+	}
+
+	if c.handler == nil {
+		h := defaultHandler{
+			appKey:       appKey,
+			masterSecret: masterSecret,
+			checkAuth:    c.checkAuth,
+			received:     c.received,
+			clicked:      c.clicked,
+			notReceived:  c.notReceived,
+			push:         c.push,
+			unified:      c.unified,
+		}
+		c.handler = http.HandlerFunc(h.Callback)
+	}
+
+	return &Server{
+		server: &http.Server{
+			Addr:    c.addr,
+			Handler: c.handler,
+		},
+		path:   c.path,
+		logger: c.logger,
+	}, nil
+}
+
+// 实现 http.Handler 接口，以便将回调接口服务挂载到已有的 HTTP 服务中。
+func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.server.Handler.ServeHTTP(w, r)
+}
+
+// 处理回调请求。
+func (srv *Server) Handle(w http.ResponseWriter, r *http.Request) error {
+	srv.server.Handler.ServeHTTP(w, r)
+	return nil
+}
+
+// 启动回调接口服务。
+func (srv *Server) Run() error {
+	if srv.hasStarted() {
+		return errors.New("JPush callback server is already running")
+	}
+
+	srv.start()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	srv.logger.Infof(context.TODO(), "正在启动极光推送回执回调接口服务，监听地址为 %s，回调路径为 %s", srv.server.Addr, srv.path)
+
+	startCh, errorCh := make(chan struct{}), make(chan error, 1)
+
+	go func() {
+		defer wg.Done()
+
+		ln, err := net.Listen("tcp", srv.server.Addr)
+		if err != nil {
+			errorCh <- err
+			return
+		}
+		close(startCh)
+
+		if err = srv.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			errorCh <- err
+		}
+	}()
+
+	go srv.autoStop()
+
+	select {
+	case <-startCh:
+		srv.logger.Infof(context.TODO(), "极光推送回执回调接口服务启动成功！")
+	case err := <-errorCh:
+		srv.logger.Errorf(context.TODO(), "极光推送回执回调接口服务启动失败：%s", err)
+		return err
+	case <-time.After(time.Second * 5):
+		srv.logger.Error(context.TODO(), "极光推送回执回调接口服务启动超时！")
+		return errors.New("JPush callback server startup timeout")
+	}
+
+	wg.Wait()
+
+	return nil
+}
+
+// 监听系统信号（如 SIGINT、SIGTERM 等），自动停止回调接口服务。
+func (srv *Server) autoStop() {
+	stopCh := make(chan os.Signal, 1)
+	signal.Notify(stopCh, syscall.SIGINT, syscall.SIGTERM)
+
+	sig := <-stopCh // 等待接收到停止信号。
+
+	srv.logger.Infof(context.TODO(), "接收到停止信号：%s！", strings.ToUpper(sig.String()))
+
+	if srv.hasStarted() {
+		srv.stop()
+	} else {
+		srv.logger.Info(context.TODO(), "极光推送回执回调接口服务已停止！")
+		os.Exit(-1)
+	}
+
+	srv.logger.Info(context.TODO(), "正在停止极光推送回执回调接口服务...")
+	// 使用 5 秒钟的宽限时间来优雅关闭服务。
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.server.Shutdown(ctx); err != nil {
+		srv.logger.Warnf(context.TODO(), "极光推送回执回调接口服务优雅停止失败：%s，正在尝试强制停止...", err)
+		if err = srv.server.Close(); err != nil {
+			srv.logger.Errorf(context.TODO(), "极光推送回执回调接口服务强制停止失败：%s，直接退出！", err)
+			os.Exit(1)
+		}
+	}
+	srv.logger.Info(context.TODO(), "极光推送回执回调接口服务已停止！")
+	os.Exit(0)
+}
+
+func (srv *Server) hasStarted() bool {
+	srv.mu.RLock()
+	defer srv.mu.RUnlock()
+	return srv.isRunning
+}
+
+func (srv *Server) start() {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	mux := http.NewServeMux()
+	mux.Handle(srv.path, srv.server.Handler)
+	srv.isRunning = true
+}
+
+func (srv *Server) stop() {
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	srv.isRunning = false
+}
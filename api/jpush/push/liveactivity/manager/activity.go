@@ -0,0 +1,169 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+// # 实时活动状态
+type Status string
+
+const (
+	StatusActive Status = "active" // 进行中（已创建，尚未结束）
+	StatusEnded  Status = "ended"  // 已结束
+)
+
+// # 实时活动
+//
+// 管理器中记录的某个实时活动（以 LiveActivityID 标识）的当前状态快照。
+type Activity struct {
+	ID             string                 `json:"id"`                        // 实时活动标识，对应 iOS SDK liveActivityId 的值
+	Status         Status                 `json:"status"`                    // 实时活动状态
+	AttributesType string                 `json:"attributes_type,omitempty"` // 实时活动属性类型
+	ContentState   map[string]interface{} `json:"content_state,omitempty"`   // 最近一次下发的实时活动动态内容
+	MsgIDs         []string               `json:"msg_ids,omitempty"`         // 该实时活动所有推送消息的 msg_id，按下发顺序排列
+	UpdateCount    int                    `json:"update_count"`              // 更新次数（不包括创建和结束）
+	StaleDate      time.Time              `json:"stale_date"`                // 最近一次下发的显示过期时间
+	DismissalDate  time.Time              `json:"dismissal_date"`            // 结束展示时间
+	StartedAt      time.Time              `json:"started_at"`                // 创建时间
+	UpdatedAt      time.Time              `json:"updated_at"`                // 最近一次下发时间
+	EndedAt        time.Time              `json:"ended_at"`                  // 结束时间
+}
+
+// 最近一次推送消息的 msg_id。
+func (a *Activity) LastMsgID() string {
+	if a == nil || len(a.MsgIDs) == 0 {
+		return ""
+	}
+	return a.MsgIDs[len(a.MsgIDs)-1]
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 实时活动优先级
+type Priority int
+
+const (
+	PriorityAuto Priority = 0  // 自动：频控预算内使用 10，超出预算后降级为 5
+	PriorityLow  Priority = 5  // 低优先级，不消耗苹果厂商频控配额
+	PriorityHigh Priority = 10 // 高优先级，消耗苹果厂商频控配额
+)
+
+// # 创建实时活动参数
+type StartParam struct {
+	// 【必填】实时活动属性类型，需与客户端 SDK 值匹配。
+	AttributesType string
+	// 【可选】实时活动属性。
+	Attributes map[string]interface{}
+	// 【必填】实时活动动态内容，可以是任意能被 JSON 序列化为对象的自定义结构体，也可以直接使用 map[string]interface{}。
+	ContentState interface{}
+	// 【可选】实时活动通知内容。
+	Alert *liveactivity.IosAlertMessage
+	// 【可选】实时活动显示过期时间，为零值时按 WithStaleAfter 的配置自动设置。
+	StaleDate time.Time
+	// 【可选】实时活动在灵动岛上展示的优先级，取值范围为 [1, 100]。
+	RelevanceScore int
+	// 【可选】推送可选项。
+	Options *options.Options
+}
+
+// # 更新实时活动参数
+type UpdateParam struct {
+	// 【必填】实时活动动态内容，可以是任意能被 JSON 序列化为对象的自定义结构体，也可以直接使用 map[string]interface{}。
+	ContentState interface{}
+	// 【可选】实时活动通知内容。
+	Alert *liveactivity.IosAlertMessage
+	// 【可选】实时活动显示过期时间，为零值时按 WithStaleAfter 的配置自动设置。
+	StaleDate time.Time
+	// 【可选】实时活动在灵动岛上展示的优先级，取值范围为 [1, 100]。
+	RelevanceScore int
+	// 【可选】更新优先级，默认为 PriorityAuto。
+	Priority Priority
+	// 【可选】推送可选项。
+	Options *options.Options
+}
+
+// # 结束实时活动参数
+type EndParam struct {
+	// 【可选】实时活动最终的动态内容，为 nil 时沿用最近一次下发的内容。
+	ContentState interface{}
+	// 【可选】实时活动通知内容。
+	Alert *liveactivity.IosAlertMessage
+	// 【可选】实时活动结束展示时间，为零值时按 WithDismissAfter 的配置自动设置。
+	DismissalDate time.Time
+	// 【可选】推送可选项。
+	Options *options.Options
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 将自定义的动态内容转换为 content-state 对象。
+//
+// 始终经过一次 JSON 序列化，返回的对象与调用方传入的值（包括 map[string]interface{}）不共享任何数据。
+func toContentState(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber() // 保持数值精度
+	if err = dec.Decode(&m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func copyActivity(a *activity) Activity {
+	s := a.Activity
+	s.MsgIDs = append([]string(nil), a.MsgIDs...)
+	if a.ContentState != nil {
+		s.ContentState = copyValue(a.ContentState).(map[string]interface{})
+	}
+	return s
+}
+
+// 深拷贝 toContentState 解码得到的值（对象、数组及标量）。
+func copyValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			m[k] = copyValue(e)
+		}
+		return m
+	case []interface{}:
+		l := make([]interface{}, len(v))
+		for i, e := range v {
+			l[i] = copyValue(e)
+		}
+		return l
+	default:
+		return v
+	}
+}
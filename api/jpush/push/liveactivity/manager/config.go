@@ -0,0 +1,166 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultUpdateBudget = 10        // 默认每个统计窗口内允许的高优先级（ApnsPriority = 10）更新次数
+	defaultBudgetWindow = time.Hour // 默认的更新频控统计窗口
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 实时活动管理器配置。
+type config struct {
+	report       report.APIv3   // 推送统计 API，用于查询实时活动消息的统计数据，为 nil 时无法调用 Manager.GetStats
+	logger       jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	updateBudget int            // 每个统计窗口内允许的高优先级更新次数，默认为 10
+	budgetWindow time.Duration  // 更新频控统计窗口，默认为 1 小时
+	strict       bool           // 高优先级更新次数超出预算时是否直接返回错误，默认为 false，即自动降级为 ApnsPriority = 5
+	staleAfter   time.Duration  // 创建或更新后多久实时活动显示过期，为 0 时不设置 stale-date
+	dismissAfter time.Duration  // 结束后多久从锁屏移除实时活动，为 0 时不设置 dismissal-date（由系统决定）
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 实时活动管理器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 推送统计 API 配置选项。
+type reportAPIOption struct {
+	report report.APIv3
+}
+
+func (o reportAPIOption) apply(c *config) error {
+	if o.report == nil {
+		return errors.New("`report` cannot be nil")
+	}
+	c.report = o.report
+	return nil
+}
+
+// 配置推送统计 API，用于通过 Manager.GetStats 查询实时活动消息的统计数据。
+func WithReportAPI(report report.APIv3) ConfigOption {
+	return reportAPIOption{report}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置实时活动管理器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 更新频控预算配置选项。
+type updateBudgetOption struct {
+	budget int
+	window time.Duration
+}
+
+func (o updateBudgetOption) apply(c *config) error {
+	if o.budget < 0 {
+		return errors.New("`budget` cannot be negative")
+	}
+	if o.window <= 0 {
+		return errors.New("`window` must be positive")
+	}
+	c.updateBudget = o.budget
+	c.budgetWindow = o.window
+	return nil
+}
+
+// 自定义配置每个实时活动在 `window` 时间窗口内允许的高优先级（ApnsPriority = 10）更新次数，默认为每小时 10 次。
+//
+// 苹果对实时活动的高优先级更新有频控限制，超出预算的更新默认会自动降级为 ApnsPriority = 5（不消耗苹果厂商频控配额）。
+func WithUpdateBudget(budget int, window time.Duration) ConfigOption {
+	return updateBudgetOption{budget, window}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 严格频控配置选项。
+type strictBudgetOption bool
+
+func (o strictBudgetOption) apply(c *config) error {
+	c.strict = bool(o)
+	return nil
+}
+
+// 配置高优先级更新次数超出预算时直接返回 ErrUpdateBudgetExceeded 错误，而不是自动降级为 ApnsPriority = 5。
+func WithStrictBudget() ConfigOption {
+	return strictBudgetOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 显示过期时长配置选项。
+type staleAfterOption time.Duration
+
+func (o staleAfterOption) apply(c *config) error {
+	if o < 0 {
+		return errors.New("`staleAfter` cannot be negative")
+	}
+	c.staleAfter = time.Duration(o)
+	return nil
+}
+
+// 自定义配置实时活动创建或更新后的显示过期时长，管理器会据此自动设置 stale-date，为 0 时不设置。
+func WithStaleAfter(d time.Duration) ConfigOption {
+	return staleAfterOption(d)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 结束展示时长配置选项。
+type dismissAfterOption time.Duration
+
+func (o dismissAfterOption) apply(c *config) error {
+	if o < 0 {
+		return errors.New("`dismissAfter` cannot be negative")
+	}
+	c.dismissAfter = time.Duration(o)
+	return nil
+}
+
+// 自定义配置实时活动结束后在锁屏上继续展示的时长，管理器会据此自动设置 dismissal-date，为 0 时不设置（由系统决定）。
+func WithDismissAfter(d time.Duration) ConfigOption {
+	return dismissAfterOption(d)
+}
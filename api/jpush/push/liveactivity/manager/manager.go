@@ -0,0 +1,471 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+)
+
+var (
+	ErrActivityNotFound     = errors.New("live activity not found")              // 实时活动不存在（未通过管理器创建）
+	ErrActivityExists       = errors.New("live activity is already active")      // 实时活动已存在且尚未结束
+	ErrActivityEnded        = errors.New("live activity has already ended")      // 实时活动已结束
+	ErrUpdateBudgetExceeded = errors.New("live activity update budget exceeded") // 高优先级更新次数超出预算
+	ErrNilReportAPI         = errors.New("report api is not configured")         // 未配置推送统计 API
+)
+
+// # 实时活动管理器
+//
+// 基于 push.APIv3 封装 iOS 实时活动（Live Activity）的完整生命周期：创建（start）、更新（update）和结束（end），
+// 并以 LiveActivityID 为标识跟踪每个实时活动的当前状态。
+//   - 动态内容可以直接使用自定义结构体，由管理器序列化为 content-state；
+//   - 自动设置 stale-date 和 dismissal-date；
+//   - 按 WithUpdateBudget 的配置控制高优先级更新次数，避免超出苹果的频控限制；
+//   - 配置 WithReportAPI 后，可以通过 GetStats 查询实时活动消息的统计数据。
+type Manager struct {
+	push       push.APIv3
+	cfg        config
+	mu         sync.Mutex
+	activities map[string]*activity
+	starting   map[string]struct{} // 正在下发 start 事件的 LiveActivityID
+	now        func() time.Time
+}
+
+// 管理器内部记录的实时活动。
+type activity struct {
+	Activity
+	highUpdates []time.Time // 统计窗口内高优先级更新的下发时间
+	op          sync.Mutex  // 串行化同一实时活动的 update 和 end 事件
+}
+
+// 创建新的实时活动管理器实例。
+func NewManager(pushAPI push.APIv3, opts ...ConfigOption) (*Manager, error) {
+	if pushAPI == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+
+	c := config{
+		logger:       api.DefaultJPushLogger,
+		updateBudget: defaultUpdateBudget,
+		budgetWindow: defaultBudgetWindow,
+	}
+
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Manager{
+		push:       pushAPI,
+		cfg:        c,
+		activities: make(map[string]*activity),
+		starting:   make(map[string]struct{}),
+		now:        time.Now,
+	}, nil
+}
+
+// # 创建实时活动
+//
+// 向 LiveActivityID 为 `id` 的实时活动下发 start 事件。若该实时活动已存在且尚未结束（或正在创建），则返回 ErrActivityExists。
+func (m *Manager) Start(ctx context.Context, id string, param *StartParam) (*push.SendResult, error) {
+	if id == "" {
+		return nil, errors.New("`id` cannot be empty")
+	}
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	if param.AttributesType == "" {
+		return nil, errors.New("`param.AttributesType` cannot be empty")
+	}
+	if param.ContentState == nil {
+		return nil, errors.New("`param.ContentState` cannot be nil")
+	}
+
+	contentState, err := toContentState(param.ContentState)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	if _, ok := m.starting[id]; ok {
+		m.mu.Unlock()
+		return nil, ErrActivityExists
+	}
+	if a, ok := m.activities[id]; ok && a.Status == StatusActive {
+		m.mu.Unlock()
+		return nil, ErrActivityExists
+	}
+	m.starting[id] = struct{}{} // 预占 ID，避免并发创建同一实时活动
+	m.mu.Unlock()
+
+	now := m.now()
+	staleDate := m.staleDate(now, param.StaleDate)
+	msg := &liveactivity.IosMessage{
+		Event:          liveactivity.EventStart,
+		ContentState:   contentState,
+		AttributesType: param.AttributesType,
+		Attributes:     param.Attributes,
+		Alert:          param.Alert,
+		StaleDate:      unixOrZero(staleDate),
+		RelevanceScore: param.RelevanceScore,
+	}
+
+	result, err := m.send(ctx, id, msg, param.Options)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.starting, id)
+	if err != nil {
+		return result, err
+	}
+	m.activities[id] = &activity{Activity: Activity{
+		ID:             id,
+		Status:         StatusActive,
+		AttributesType: param.AttributesType,
+		ContentState:   contentState,
+		MsgIDs:         []string{result.MsgID},
+		StaleDate:      staleDate,
+		StartedAt:      now,
+		UpdatedAt:      now,
+	}}
+	m.cfg.logger.Debugf(ctx, "实时活动 %s 已创建，msg_id：%s", id, result.MsgID)
+	return result, nil
+}
+
+// # 更新实时活动
+//
+// 向 LiveActivityID 为 `id` 的实时活动下发 update 事件。
+//   - 若该实时活动未通过管理器创建，则返回 ErrActivityNotFound；若已结束，则返回 ErrActivityEnded；
+//   - 当 Priority 为 PriorityAuto 时，频控预算内使用 ApnsPriority = 10，超出预算后自动降级为 ApnsPriority = 5；
+//   - 当 Priority 为 PriorityHigh 且超出预算时，若配置了 WithStrictBudget 则返回 ErrUpdateBudgetExceeded，否则仍按高优先级下发。
+func (m *Manager) Update(ctx context.Context, id string, param *UpdateParam) (*push.SendResult, error) {
+	if id == "" {
+		return nil, errors.New("`id` cannot be empty")
+	}
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	if param.ContentState == nil {
+		return nil, errors.New("`param.ContentState` cannot be nil")
+	}
+	switch param.Priority {
+	case PriorityAuto, PriorityLow, PriorityHigh:
+	default:
+		return nil, fmt.Errorf("invalid `param.Priority` %d, must be 0, 5 or 10", param.Priority)
+	}
+
+	contentState, err := toContentState(param.ContentState)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := m.acquire(id)
+	if err != nil {
+		return nil, err
+	}
+	defer a.op.Unlock()
+
+	now := m.now()
+	m.mu.Lock()
+	priority, err := m.reserve(ctx, a, now, param.Priority)
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	staleDate := m.staleDate(now, param.StaleDate)
+	msg := &liveactivity.IosMessage{
+		Event:          liveactivity.EventUpdate,
+		ContentState:   contentState,
+		Alert:          param.Alert,
+		StaleDate:      unixOrZero(staleDate),
+		RelevanceScore: param.RelevanceScore,
+		ApnsPriority:   int(priority),
+	}
+
+	result, err := m.send(ctx, id, msg, param.Options)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err != nil {
+		if priority == PriorityHigh {
+			a.release(now)
+		}
+		return result, err
+	}
+	a.ContentState = contentState
+	a.MsgIDs = append(a.MsgIDs, result.MsgID)
+	a.UpdateCount++
+	a.StaleDate = staleDate
+	a.UpdatedAt = now
+	m.cfg.logger.Debugf(ctx, "实时活动 %s 已更新（apns-priority：%d），msg_id：%s", id, priority, result.MsgID)
+	return result, nil
+}
+
+// # 结束实时活动
+//
+// 向 LiveActivityID 为 `id` 的实时活动下发 end 事件，`param` 可以为 nil。
+// 若该实时活动未通过管理器创建，则返回 ErrActivityNotFound；若已结束，则返回 ErrActivityEnded。
+func (m *Manager) End(ctx context.Context, id string, param *EndParam) (*push.SendResult, error) {
+	if id == "" {
+		return nil, errors.New("`id` cannot be empty")
+	}
+	if param == nil {
+		param = &EndParam{}
+	}
+
+	a, err := m.acquire(id)
+	if err != nil {
+		return nil, err
+	}
+	defer a.op.Unlock()
+
+	m.mu.Lock()
+	contentState := a.ContentState
+	m.mu.Unlock()
+	if param.ContentState != nil {
+		if contentState, err = toContentState(param.ContentState); err != nil {
+			return nil, err
+		}
+	}
+
+	now := m.now()
+	dismissalDate := param.DismissalDate
+	if dismissalDate.IsZero() && m.cfg.dismissAfter > 0 {
+		dismissalDate = now.Add(m.cfg.dismissAfter)
+	}
+	msg := &liveactivity.IosMessage{
+		Event:         liveactivity.EventEnd,
+		ContentState:  contentState,
+		Alert:         param.Alert,
+		DismissalDate: unixOrZero(dismissalDate),
+	}
+
+	result, err := m.send(ctx, id, msg, param.Options)
+	if err != nil {
+		return result, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a.Status = StatusEnded
+	a.ContentState = contentState
+	a.MsgIDs = append(a.MsgIDs, result.MsgID)
+	a.DismissalDate = dismissalDate
+	a.UpdatedAt = now
+	a.EndedAt = now
+	a.highUpdates = nil
+	m.cfg.logger.Debugf(ctx, "实时活动 %s 已结束，msg_id：%s", id, result.MsgID)
+	return result, nil
+}
+
+// 获取 LiveActivityID 为 `id` 的实时活动的当前状态快照。
+func (m *Manager) Activity(id string) (Activity, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.activities[id]
+	if !ok {
+		return Activity{}, false
+	}
+	return copyActivity(a), true
+}
+
+// 获取管理器中所有实时活动的当前状态快照，按创建时间排序。
+func (m *Manager) Activities() []Activity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	list := make([]Activity, 0, len(m.activities))
+	for _, a := range m.activities {
+		list = append(list, copyActivity(a))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].StartedAt.Before(list[j].StartedAt)
+	})
+	return list
+}
+
+// 获取 LiveActivityID 为 `id` 的实时活动在当前统计窗口内剩余的高优先级更新次数。
+func (m *Manager) RemainingBudget(id string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	a, ok := m.activities[id]
+	if !ok {
+		return m.cfg.updateBudget
+	}
+	a.prune(m.now(), m.cfg.budgetWindow)
+	return m.cfg.updateBudget - len(a.highUpdates)
+}
+
+// 从管理器中移除 LiveActivityID 为 `id` 的实时活动记录（不会下发任何推送）。
+func (m *Manager) Forget(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.activities, id)
+}
+
+// # 查询实时活动统计数据
+//
+// 通过 report.APIv3 的 GetMessageDetail 查询 LiveActivityID 为 `id` 的实时活动所有推送消息的统计数据，返回以 msg_id 为键的 LiveActivityStats。
+// 需要通过 WithReportAPI 配置推送统计 API，否则返回 ErrNilReportAPI。
+func (m *Manager) GetStats(ctx context.Context, id string) (map[string]*report.LiveActivityStats, error) {
+	if m.cfg.report == nil {
+		return nil, ErrNilReportAPI
+	}
+
+	a, ok := m.Activity(id)
+	if !ok {
+		return nil, ErrActivityNotFound
+	}
+
+	stats := make(map[string]*report.LiveActivityStats, len(a.MsgIDs))
+	for start := 0; start < len(a.MsgIDs); start += 100 {
+		end := start + 100
+		if end > len(a.MsgIDs) {
+			end = len(a.MsgIDs)
+		}
+		result, err := m.cfg.report.GetMessageDetail(ctx, a.MsgIDs[start:end])
+		if err != nil {
+			return nil, err
+		}
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, err
+		}
+		for _, detail := range result.MessageDetails {
+			if detail.Details != nil && detail.Details.LiveActivity != nil {
+				stats[detail.MsgID] = detail.Details.LiveActivity
+			}
+		}
+	}
+	return stats, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (m *Manager) send(ctx context.Context, id string, msg *liveactivity.IosMessage, opts *push.Options) (*push.SendResult, error) {
+	param := &push.SendParam{
+		Platform:     []platform.Platform{platform.IOS},
+		Audience:     &audience.Audience{LiveActivityID: id},
+		Options:      opts,
+		LiveActivity: &liveactivity.Message{IOS: msg},
+	}
+	result, err := m.push.Send(ctx, param)
+	if err == nil {
+		err = api.CheckResponse(result.Response, result.Error)
+	}
+	if err != nil {
+		m.cfg.logger.Errorf(ctx, "实时活动 %s 下发 %s 事件失败：%s", id, msg.Event, err)
+	}
+	return result, err
+}
+
+// 获取进行中的实时活动并锁定，保证同一实时活动同时只有一个 update 或 end 事件在下发，调用方需在完成后调用 a.op.Unlock。
+//
+// 锁定后会重新检查状态，以免在等待期间该实时活动已被结束、移除或重新创建。
+func (m *Manager) acquire(id string) (*activity, error) {
+	m.mu.Lock()
+	a, err := m.get(id)
+	m.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	a.op.Lock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.activities[id]
+	if !ok {
+		a.op.Unlock()
+		return nil, ErrActivityNotFound
+	}
+	if current != a || a.Status == StatusEnded {
+		a.op.Unlock()
+		return nil, ErrActivityEnded
+	}
+	return a, nil
+}
+
+// 获取进行中的实时活动，调用方需持有锁。
+func (m *Manager) get(id string) (*activity, error) {
+	a, ok := m.activities[id]
+	if !ok {
+		return nil, ErrActivityNotFound
+	}
+	if a.Status == StatusEnded {
+		return nil, ErrActivityEnded
+	}
+	return a, nil
+}
+
+// 按频控预算确定本次更新的实际优先级，若为高优先级则预占一次预算，调用方需持有锁。
+func (m *Manager) reserve(ctx context.Context, a *activity, now time.Time, priority Priority) (Priority, error) {
+	if priority == PriorityLow {
+		return PriorityLow, nil
+	}
+
+	a.prune(now, m.cfg.budgetWindow)
+	if len(a.highUpdates) >= m.cfg.updateBudget {
+		if priority == PriorityAuto {
+			m.cfg.logger.Infof(ctx, "实时活动 %s 高优先级更新次数已达预算上限 %d，本次更新降级为 apns-priority: 5", a.ID, m.cfg.updateBudget)
+			return PriorityLow, nil
+		}
+		if m.cfg.strict {
+			return PriorityHigh, ErrUpdateBudgetExceeded
+		}
+		m.cfg.logger.Warnf(ctx, "实时活动 %s 高优先级更新次数已达预算上限 %d，本次更新可能被苹果限流", a.ID, m.cfg.updateBudget)
+	}
+	a.highUpdates = append(a.highUpdates, now)
+	return PriorityHigh, nil
+}
+
+func (m *Manager) staleDate(now, staleDate time.Time) time.Time {
+	if staleDate.IsZero() && m.cfg.staleAfter > 0 {
+		return now.Add(m.cfg.staleAfter)
+	}
+	return staleDate
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 移除统计窗口之外的高优先级更新记录。
+func (a *activity) prune(now time.Time, window time.Duration) {
+	i := 0
+	for i < len(a.highUpdates) && now.Sub(a.highUpdates[i]) >= window {
+		i++
+	}
+	a.highUpdates = a.highUpdates[i:]
+}
+
+// 释放一次预占的高优先级更新预算。
+func (a *activity) release(at time.Time) {
+	for i := len(a.highUpdates) - 1; i >= 0; i-- {
+		if a.highUpdates[i].Equal(at) {
+			a.highUpdates = append(a.highUpdates[:i], a.highUpdates[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/liveactivity"
+)
+
+type fakePush struct {
+	push.APIv3
+	mu       sync.Mutex
+	msgs     []*liveactivity.IosMessage
+	inflight int
+	peak     int                        // 同时下发的最大请求数
+	block    chan struct{}              // 非 nil 时，Send 阻塞直到其被关闭
+	started  chan struct{}              // 非 nil 时，Send 开始后发送通知
+	fail     func(n int) *api.CodeError // 按调用序号返回失败结果
+}
+
+func (f *fakePush) Send(_ context.Context, param *push.SendParam) (*push.SendResult, error) {
+	f.mu.Lock()
+	f.msgs = append(f.msgs, param.LiveActivity.IOS)
+	n := len(f.msgs)
+	f.inflight++
+	if f.inflight > f.peak {
+		f.peak = f.inflight
+	}
+	block, started, fail := f.block, f.started, f.fail
+	f.mu.Unlock()
+
+	if started != nil {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+	}
+	if block != nil {
+		<-block
+	}
+
+	f.mu.Lock()
+	f.inflight--
+	f.mu.Unlock()
+
+	if fail != nil {
+		if codeErr := fail(n); codeErr != nil {
+			return &push.SendResult{Response: &api.Response{StatusCode: 400}, Error: codeErr}, nil
+		}
+	}
+	return &push.SendResult{Response: &api.Response{StatusCode: 200}, MsgID: strconv.Itoa(n)}, nil
+}
+
+func (f *fakePush) sent() []*liveactivity.IosMessage {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*liveactivity.IosMessage(nil), f.msgs...)
+}
+
+func newTestManager(t *testing.T, f push.APIv3, opts ...ConfigOption) *Manager {
+	t.Helper()
+	m, err := NewManager(f, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return m
+}
+
+func startParam(state map[string]interface{}) *StartParam {
+	return &StartParam{AttributesType: "Delivery", ContentState: state}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func TestStartDuplicate(t *testing.T) {
+	ctx := context.Background()
+	f := &fakePush{block: make(chan struct{}), started: make(chan struct{}, 1)}
+	m := newTestManager(t, f)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 1}))
+		done <- err
+	}()
+	<-f.started
+
+	// 第一次 start 事件尚在下发中。
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 1})); err != ErrActivityExists {
+		t.Fatalf("Start while starting = %v, want %v", err, ErrActivityExists)
+	}
+	close(f.block)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+
+	// 已创建且尚未结束。
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 1})); err != ErrActivityExists {
+		t.Fatalf("Start while active = %v, want %v", err, ErrActivityExists)
+	}
+	if n := len(f.sent()); n != 1 {
+		t.Fatalf("sent %d messages, want 1", n)
+	}
+
+	// 结束后可以重新创建。
+	if _, err := m.End(ctx, "la", nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 2})); err != nil {
+		t.Fatalf("Start after end = %v", err)
+	}
+}
+
+func TestStartFailure(t *testing.T) {
+	ctx := context.Background()
+	codeErr := &api.CodeError{Code: 1011, Message: "cannot find user by this audience"}
+	f := &fakePush{fail: func(int) *api.CodeError { return codeErr }}
+	m := newTestManager(t, f)
+
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 1})); err != codeErr {
+		t.Fatalf("Start = %v, want %v", err, codeErr)
+	}
+	if _, ok := m.Activity("la"); ok {
+		t.Fatal("failed start must not record the activity")
+	}
+}
+
+func TestUpdateEndSerialized(t *testing.T) {
+	ctx := context.Background()
+	f := &fakePush{}
+	m := newTestManager(t, f)
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 1})); err != nil {
+		t.Fatal(err)
+	}
+
+	f.mu.Lock()
+	f.block, f.started = make(chan struct{}), make(chan struct{}, 2)
+	f.mu.Unlock()
+
+	var wg sync.WaitGroup
+	var updateErr, endErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, updateErr = m.Update(ctx, "la", &UpdateParam{ContentState: map[string]interface{}{"step": 2}})
+	}()
+	<-f.started
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, endErr = m.End(ctx, "la", nil)
+	}()
+
+	// update 事件下发完成前，end 事件不得开始下发。
+	select {
+	case <-f.started:
+		t.Fatal("end was sent while update was in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(f.block)
+	wg.Wait()
+
+	if updateErr != nil || endErr != nil {
+		t.Fatalf("Update = %v, End = %v", updateErr, endErr)
+	}
+	if f.peak != 1 {
+		t.Fatalf("peak in-flight sends = %d, want 1", f.peak)
+	}
+	sent := f.sent()
+	if len(sent) != 3 || sent[1].Event != liveactivity.EventUpdate || sent[2].Event != liveactivity.EventEnd {
+		t.Fatalf("unexpected events: %d messages", len(sent))
+	}
+	if got := sent[2].ContentState["step"]; got != json.Number("2") {
+		t.Fatalf("end content-state step = %v, want 2", got)
+	}
+
+	if _, err := m.Update(ctx, "la", &UpdateParam{ContentState: map[string]interface{}{"step": 3}}); err != ErrActivityEnded {
+		t.Fatalf("Update after end = %v, want %v", err, ErrActivityEnded)
+	}
+	if _, err := m.End(ctx, "la", nil); err != ErrActivityEnded {
+		t.Fatalf("End after end = %v, want %v", err, ErrActivityEnded)
+	}
+}
+
+func TestUpdateBudget(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []ConfigOption
+		priority Priority
+		want     []int // 每次更新的 apns-priority，0 表示返回 ErrUpdateBudgetExceeded
+	}{
+		{"auto downgrades", nil, PriorityAuto, []int{10, 10, 5, 5}},
+		{"low never consumes", nil, PriorityLow, []int{5, 5, 5, 5}},
+		{"high exceeds", nil, PriorityHigh, []int{10, 10, 10, 10}},
+		{"high strict", []ConfigOption{WithStrictBudget()}, PriorityHigh, []int{10, 10, 0, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			f := &fakePush{}
+			m := newTestManager(t, f, append([]ConfigOption{WithUpdateBudget(2, time.Hour)}, tt.opts...)...)
+			if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 0})); err != nil {
+				t.Fatal(err)
+			}
+			for i, want := range tt.want {
+				before := len(f.sent())
+				_, err := m.Update(ctx, "la", &UpdateParam{ContentState: map[string]interface{}{"step": i + 1}, Priority: tt.priority})
+				if want == 0 {
+					if err != ErrUpdateBudgetExceeded {
+						t.Fatalf("update %d: err = %v, want %v", i, err, ErrUpdateBudgetExceeded)
+					}
+					if len(f.sent()) != before {
+						t.Fatalf("update %d: rejected update must not be sent", i)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("update %d: %v", i, err)
+				}
+				sent := f.sent()
+				if got := sent[len(sent)-1].ApnsPriority; got != want {
+					t.Fatalf("update %d: apns-priority = %d, want %d", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateBudgetWindow(t *testing.T) {
+	ctx := context.Background()
+	f := &fakePush{}
+	m := newTestManager(t, f, WithUpdateBudget(1, time.Minute))
+	now := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return now }
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 0})); err != nil {
+		t.Fatal(err)
+	}
+
+	lastPriority := func() int {
+		sent := f.sent()
+		return sent[len(sent)-1].ApnsPriority
+	}
+	update := func() {
+		t.Helper()
+		if _, err := m.Update(ctx, "la", &UpdateParam{ContentState: map[string]interface{}{"step": 1}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	update()
+	if got := lastPriority(); got != int(PriorityHigh) {
+		t.Fatalf("first update apns-priority = %d, want 10", got)
+	}
+	update()
+	if got := lastPriority(); got != int(PriorityLow) {
+		t.Fatalf("second update apns-priority = %d, want 5", got)
+	}
+	if got := m.RemainingBudget("la"); got != 0 {
+		t.Fatalf("RemainingBudget = %d, want 0", got)
+	}
+
+	now = now.Add(time.Minute)
+	update()
+	if got := lastPriority(); got != int(PriorityHigh) {
+		t.Fatalf("update after window apns-priority = %d, want 10", got)
+	}
+}
+
+func TestUpdateFailureReleasesBudget(t *testing.T) {
+	ctx := context.Background()
+	f := &fakePush{fail: func(n int) *api.CodeError {
+		if n == 2 {
+			return &api.CodeError{Code: 1001, Message: "system error"}
+		}
+		return nil
+	}}
+	m := newTestManager(t, f, WithUpdateBudget(1, time.Hour))
+	if _, err := m.Start(ctx, "la", startParam(map[string]interface{}{"step": 0})); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Update(ctx, "la", &UpdateParam{ContentState: map[string]interface{}{"step": 1}}); err == nil {
+		t.Fatal("Update should fail")
+	}
+	if got := m.RemainingBudget("la"); got != 1 {
+		t.Fatalf("RemainingBudget after failed update = %d, want 1", got)
+	}
+	a, _ := m.Activity("la")
+	if a.UpdateCount != 0 || len(a.MsgIDs) != 1 {
+		t.Fatalf("failed update must not change the activity: %+v", a)
+	}
+}
+
+func TestCopyActivity(t *testing.T) {
+	ctx := context.Background()
+	f := &fakePush{}
+	m := newTestManager(t, f)
+	state := map[string]interface{}{
+		"driver": map[string]interface{}{"name": "Alice"},
+		"stops":  []interface{}{"A", "B"},
+	}
+	if _, err := m.Start(ctx, "la", startParam(state)); err != nil {
+		t.Fatal(err)
+	}
+
+	// 修改调用方传入的值。
+	state["driver"].(map[string]interface{})["name"] = "Bob"
+
+	a, _ := m.Activity("la")
+	a.MsgIDs[0] = "changed"
+	a.ContentState["driver"].(map[string]interface{})["name"] = "Carol"
+	a.ContentState["stops"].([]interface{})[0] = "Z"
+	a.ContentState["extra"] = true
+
+	list := m.Activities()
+	list[0].ContentState["stops"].([]interface{})[1] = "Y"
+
+	got, _ := m.Activity("la")
+	if got.MsgIDs[0] != "1" {
+		t.Errorf("MsgIDs[0] = %q, want %q", got.MsgIDs[0], "1")
+	}
+	if name := got.ContentState["driver"].(map[string]interface{})["name"]; name != "Alice" {
+		t.Errorf("driver.name = %v, want Alice", name)
+	}
+	stops := got.ContentState["stops"].([]interface{})
+	if stops[0] != "A" || stops[1] != "B" {
+		t.Errorf("stops = %v, want [A B]", stops)
+	}
+	if _, ok := got.ContentState["extra"]; ok {
+		t.Error("snapshot mutation leaked into the manager")
+	}
+}
+
+func TestSendCheckResponse(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *push.SendResult
+		err    error
+	}{
+		{"code error", &push.SendResult{Response: &api.Response{StatusCode: 400}, Error: &api.CodeError{Code: 1003}}, nil},
+		{"status code", &push.SendResult{Response: &api.Response{StatusCode: 502}}, nil},
+		{"transport", nil, errors.New("connection reset")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newTestManager(t, &resultPush{result: tt.result, err: tt.err})
+			if _, err := m.Start(context.Background(), "la", startParam(map[string]interface{}{"step": 0})); err == nil {
+				t.Fatal("Start should fail")
+			}
+			if _, ok := m.Activity("la"); ok {
+				t.Fatal("failed start must not record the activity")
+			}
+		})
+	}
+}
+
+type resultPush struct {
+	push.APIv3
+	result *push.SendResult
+	err    error
+}
+
+func (p *resultPush) Send(context.Context, *push.SendParam) (*push.SendResult, error) {
+	return p.result, p.err
+}
@@ -0,0 +1,73 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localized
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const defaultTagPrefix = "lang_" // 默认的语言标签前缀
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 多语言推送器配置。
+type config struct {
+	logger    jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	tagPrefix string         // 语言标签前缀，默认为 "lang_"，即语言 "zh" 对应的设备标签为 "lang_zh"
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 多语言推送器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置多语言推送器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 语言标签前缀配置选项。
+type tagPrefixOption string
+
+func (o tagPrefixOption) apply(c *config) error {
+	c.tagPrefix = string(o)
+	return nil
+}
+
+// 自定义配置语言标签前缀，默认为 "lang_"，即通过 device.SetDevice 为设备设置的语言标签为 "lang_zh"、"lang_en" 等。
+func WithTagPrefix(prefix string) ConfigOption {
+	return tagPrefixOption(prefix)
+}
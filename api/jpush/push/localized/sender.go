@@ -0,0 +1,378 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package localized
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/alert"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+const maxTags = 20 // 一次推送最多的标签数
+
+// # 单个语言的通知内容
+type Content struct {
+	Title string // 【可选】通知标题。
+	Alert string // 【必填】通知内容。
+}
+
+// # 多语言推送参数
+type Param struct {
+	// 【必填】推送平台，支持 platform.Android、platform.IOS、platform.HMOS 的组合列表，或 platform.All。
+	Platforms []platform.Platform
+	// 【必填】各语言的通知内容，key 为语言（如 "zh"、"en"），对应的设备标签为「语言标签前缀 + 语言」（如 "lang_zh"）。
+	Translations map[string]Content
+	// 【必填】兜底语言，必须包含在 Translations 中。兜底语言的推送会排除所有其他语言标签的设备，因此未设置语言标签的设备也会收到兜底语言的通知。
+	DefaultLocale string
+	// 【可选】通知模板，用于设置各平台除通知标题和内容以外的其他参数（如 Android.ChannelID、HMOS.Category、IOS.Sound 等）。
+	// 每个语言的推送都会复制模板并填充对应语言的通知标题和内容，模板本身不会被修改。
+	Template *notification.Notification
+	// 【可选】iOS 本地化通知内容，使用 LocKey / LocArgs（以及 TitleLocKey / TitleLocArgs 等）由 App 的 Localizable.strings 完成本地化。
+	// 设置后，iOS 平台不再按语言分别推送，而是单独发起一次 iOS 推送，其他平台仍按语言分别推送。
+	IosLocalization *alert.IosAlert
+	// 【可选】附加的标签 AND 列表，用于进一步限定每个语言推送的目标设备（取交集）。
+	AndTags []string
+	// 【可选】附加的标签 NOT 列表，用于进一步排除每个语言推送的目标设备。
+	NotTags []string
+	// 【可选】推送可选项，每个语言的推送会各自复制一份。
+	Options *options.Options
+}
+
+// # 单次推送
+type Push struct {
+	Locale string           // 语言，iOS 本地化推送时为空
+	Param  *push.SendParam  // 推送参数
+	MsgID  string           // 推送消息 ID，推送失败时为空
+	Result *push.SendResult // 推送接口的响应结果，请求失败时为 nil
+	Err    error            // 推送失败的原因，推送成功时为 nil
+}
+
+// 是否为 iOS 本地化推送（IosLocalization）。
+func (p *Push) IsIosLocalized() bool {
+	return p.Locale == ""
+}
+
+func (p *Push) IsSuccess() bool {
+	return p != nil && p.Err == nil
+}
+
+// # 多语言推送结果
+type Result struct {
+	Pushes []Push // 各语言的推送结果，兜底语言排在最后
+}
+
+// 所有推送成功的消息 ID 列表。
+func (rs *Result) MsgIDs() []string {
+	msgIDs := make([]string, 0, len(rs.Pushes))
+	for i := range rs.Pushes {
+		if rs.Pushes[i].MsgID != "" {
+			msgIDs = append(msgIDs, rs.Pushes[i].MsgID)
+		}
+	}
+	return msgIDs
+}
+
+// 推送失败的列表。
+func (rs *Result) Failed() []Push {
+	var failed []Push
+	for i := range rs.Pushes {
+		if !rs.Pushes[i].IsSuccess() {
+			failed = append(failed, rs.Pushes[i])
+		}
+	}
+	return failed
+}
+
+// 是否所有推送均成功。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && len(rs.Failed()) == 0
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 多语言推送器
+//
+// 将一条逻辑上的通知按语言拆分为多次推送：每个非兜底语言推送给带有对应语言标签（Tags）的设备，
+// 兜底语言推送给不带任何其他语言标签（NotTags）的设备，从而保证每台设备只收到一条通知。
+type Sender struct {
+	push push.APIv3
+	cfg  config
+}
+
+// 创建新的多语言推送器实例。
+func NewSender(pushAPI push.APIv3, opts ...ConfigOption) (*Sender, error) {
+	if pushAPI == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+
+	c := config{
+		logger:    api.DefaultJPushLogger,
+		tagPrefix: defaultTagPrefix,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Sender{push: pushAPI, cfg: c}, nil
+}
+
+// 构建各语言的推送参数，但不发起推送，可用于预览。
+func (s *Sender) Build(param *Param) ([]Push, error) {
+	if err := s.validate(param); err != nil {
+		return nil, err
+	}
+
+	platforms, iosSeparate := param.Platforms, false
+	if param.IosLocalization != nil {
+		platforms, iosSeparate = withoutIOS(platforms)
+	}
+
+	// 非兜底语言按字母序排列，兜底语言排在最后
+	locales := make([]string, 0, len(param.Translations))
+	for locale := range param.Translations {
+		if locale != param.DefaultLocale {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+
+	pushes := make([]Push, 0, len(locales)+2)
+	if len(platforms) > 0 {
+		otherTags := make([]string, 0, len(locales))
+		for _, locale := range locales {
+			tag := s.cfg.tagPrefix + locale
+			otherTags = append(otherTags, tag)
+			aud := &audience.Audience{Tags: []string{tag}, AndTags: param.AndTags, NotTags: param.NotTags}
+			pushes = append(pushes, Push{Locale: locale, Param: build(param, platforms, aud, param.Translations[locale])})
+		}
+
+		notTags := append(append([]string(nil), param.NotTags...), otherTags...)
+		aud := audienceOrAll(param.AndTags, notTags)
+		pushes = append(pushes, Push{
+			Locale: param.DefaultLocale,
+			Param:  build(param, platforms, aud, param.Translations[param.DefaultLocale]),
+		})
+	}
+
+	if iosSeparate {
+		pushes = append(pushes, Push{Param: buildIosLocalized(param, audienceOrAll(param.AndTags, param.NotTags))})
+	}
+	return pushes, nil
+}
+
+// 按语言分别发起推送，单次推送失败不会中断其他语言的推送，具体结果详见 Result.Pushes。
+func (s *Sender) Send(ctx context.Context, param *Param) (*Result, error) {
+	pushes, err := s.Build(param)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range pushes {
+		p := &pushes[i]
+		p.Result, p.Err = s.push.Send(ctx, p.Param)
+		if p.Err == nil {
+			p.Err = api.CheckResponse(p.Result.Response, p.Result.Error)
+		}
+		locale := p.Locale
+		if p.IsIosLocalized() {
+			locale = "ios loc-key"
+		}
+		if p.Err != nil {
+			s.cfg.logger.Warnf(ctx, "多语言推送「%s」失败：%s", locale, p.Err)
+			continue
+		}
+		p.MsgID = p.Result.MsgID
+		s.cfg.logger.Debugf(ctx, "多语言推送「%s」成功，msg_id：%s", locale, p.MsgID)
+	}
+	return &Result{Pushes: pushes}, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (s *Sender) validate(param *Param) error {
+	if param == nil {
+		return errors.New("`param` cannot be nil")
+	}
+	if len(param.Platforms) == 0 {
+		return errors.New("`Platforms` cannot be empty")
+	}
+	for _, p := range param.Platforms {
+		if p != platform.All && p != platform.Android && p != platform.IOS && p != platform.HMOS {
+			return fmt.Errorf("unsupported platform %q", p)
+		}
+	}
+	if len(param.Translations) == 0 {
+		return errors.New("`Translations` cannot be empty")
+	}
+	if _, ok := param.Translations[param.DefaultLocale]; !ok {
+		return fmt.Errorf("default locale %q has no translation", param.DefaultLocale)
+	}
+	for locale, content := range param.Translations {
+		if locale == "" {
+			return errors.New("locale cannot be empty")
+		}
+		if content.Alert == "" {
+			return fmt.Errorf("translation %q has empty alert", locale)
+		}
+	}
+	if param.IosLocalization != nil && param.IosLocalization.LocKey == "" && param.IosLocalization.Body == "" {
+		return errors.New("`IosLocalization` must have either LocKey or Body")
+	}
+	if len(param.AndTags)+1 > maxTags {
+		return fmt.Errorf("too many and-tags: %d > %d", len(param.AndTags), maxTags-1)
+	}
+	if n := len(param.NotTags) + len(param.Translations) - 1; n > maxTags {
+		return fmt.Errorf("too many not-tags for the default locale push: %d > %d", n, maxTags)
+	}
+	return nil
+}
+
+// 复制推送模板并填充指定语言的通知内容。
+func build(param *Param, platforms []platform.Platform, aud *audience.Audience, content Content) *push.SendParam {
+	n := &notification.Notification{}
+	if param.Template != nil {
+		*n = *param.Template
+	}
+	n.Alert = content.Alert
+	if n.Android != nil {
+		android := *n.Android
+		android.Alert, android.Title = content.Alert, orElse(content.Title, android.Title)
+		n.Android = &android
+	} else if content.Title != "" && includes(platforms, platform.Android) {
+		n.Android = &notification.Android{Alert: content.Alert, Title: content.Title}
+	}
+	if n.IOS != nil && !includes(platforms, platform.IOS) {
+		n.IOS = nil // iOS 平台单独推送
+	}
+	if n.IOS != nil {
+		ios := *n.IOS
+		ios.Alert = iosAlert(content)
+		n.IOS = &ios
+	} else if content.Title != "" && includes(platforms, platform.IOS) {
+		n.IOS = &notification.IOS{Alert: iosAlert(content)}
+	}
+	if n.HMOS != nil {
+		hmos := *n.HMOS
+		hmos.Alert, hmos.Title = content.Alert, orElse(content.Title, hmos.Title)
+		n.HMOS = &hmos
+	}
+
+	return &push.SendParam{
+		Platform:     platformParam(platforms),
+		Audience:     audienceParam(aud),
+		Notification: n,
+		Options:      copyOptions(param.Options),
+	}
+}
+
+// 复制推送模板并使用 iOS 本地化通知内容。
+func buildIosLocalized(param *Param, aud *audience.Audience) *push.SendParam {
+	ios := &notification.IOS{}
+	if param.Template != nil && param.Template.IOS != nil {
+		*ios = *param.Template.IOS
+	}
+	loc := *param.IosLocalization
+	ios.Alert = &loc
+
+	return &push.SendParam{
+		Platform:     []platform.Platform{platform.IOS},
+		Audience:     audienceParam(aud),
+		Notification: &notification.Notification{IOS: ios},
+		Options:      copyOptions(param.Options),
+	}
+}
+
+func iosAlert(content Content) interface{} {
+	if content.Title == "" {
+		return content.Alert
+	}
+	return &alert.IosAlert{Title: content.Title, Body: content.Alert}
+}
+
+// 移除 iOS 平台，返回剩余的平台及是否包含 iOS 平台。
+func withoutIOS(platforms []platform.Platform) ([]platform.Platform, bool) {
+	rest, hasIOS := make([]platform.Platform, 0, len(platforms)+1), false
+	for _, p := range platforms {
+		switch p {
+		case platform.All:
+			hasIOS = true
+			rest = append(rest, platform.Android, platform.HMOS)
+		case platform.IOS:
+			hasIOS = true
+		default:
+			rest = append(rest, p)
+		}
+	}
+	return rest, hasIOS
+}
+
+func includes(platforms []platform.Platform, target platform.Platform) bool {
+	for _, p := range platforms {
+		if p == platform.All || p == target {
+			return true
+		}
+	}
+	return false
+}
+
+func platformParam(platforms []platform.Platform) interface{} {
+	for _, p := range platforms {
+		if p == platform.All {
+			return platform.All
+		}
+	}
+	return platforms
+}
+
+// 没有任何标签条件时，推送给所有设备。
+func audienceOrAll(andTags, notTags []string) *audience.Audience {
+	if len(andTags) == 0 && len(notTags) == 0 {
+		return nil
+	}
+	return &audience.Audience{AndTags: andTags, NotTags: notTags}
+}
+
+func audienceParam(aud *audience.Audience) interface{} {
+	if aud == nil {
+		return audience.All
+	}
+	return aud
+}
+
+func copyOptions(o *options.Options) *options.Options {
+	if o == nil {
+		return nil
+	}
+	cp := *o
+	return &cp
+}
+
+func orElse(s, def string) string {
+	if s != "" {
+		return s
+	}
+	return def
+}
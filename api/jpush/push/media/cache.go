@@ -0,0 +1,73 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+import (
+	"sync"
+	"time"
+)
+
+// # 上传结果缓存
+//
+// 缓存图片内容摘要（及图片 URL）与极光 MediaID 的映射，避免重复下载和上传，实现需要保证并发安全。
+type Cache interface {
+	// 获取缓存的 MediaID。
+	Get(key string) (mediaID string, ok bool)
+	// 缓存 MediaID。
+	Set(key, mediaID string)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 内存缓存
+//
+// 默认的上传结果缓存，缓存项在 `ttl` 后过期。
+type MemoryCache struct {
+	mu    sync.Mutex
+	ttl   time.Duration
+	items map[string]memoryCacheItem
+}
+
+type memoryCacheItem struct {
+	mediaID  string
+	expireAt time.Time
+}
+
+// 创建新的内存缓存，缓存项在 `ttl` 后过期。
+//
+// 注意：极光默认最多保存图片 30 天，`ttl` 应小于 30 天。
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{ttl: ttl, items: make(map[string]memoryCacheItem)}
+}
+
+func (c *MemoryCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	item, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(item.expireAt) {
+		delete(c.items, key)
+		return "", false
+	}
+	return item.mediaID, true
+}
+
+func (c *MemoryCache) Set(key, mediaID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.items[key] = memoryCacheItem{mediaID: mediaID, expireAt: time.Now().Add(c.ttl)}
+}
@@ -0,0 +1,137 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const defaultCacheTTL = 29 * 24 * time.Hour // 默认的上传结果缓存时长，略短于极光图片的默认保存时长（30 天）
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 图片上传器配置。
+type config struct {
+	logger     jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	cache      Cache          // 上传结果缓存，默认为 29 天过期的内存缓存
+	client     api.Client     // 下载 URL 图片以计算内容摘要的客户端，默认为 api.DefaultClient
+	localFiles bool           // 是否上传以 "file://" 开头的本地图片文件，默认为 false
+	xiaomiFile bool           // 本地文件是否同时上传到小米通道，默认为 false，仅上传到 OPPO 通道
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 图片上传器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置图片上传器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 上传结果缓存配置选项。
+type cacheOption struct {
+	cache Cache
+}
+
+func (o cacheOption) apply(c *config) error {
+	if o.cache == nil {
+		return errors.New("`cache` cannot be nil")
+	}
+	c.cache = o.cache
+	return nil
+}
+
+// 自定义配置上传结果缓存（如使用 Redis 等在多个实例间共享），默认为 29 天过期的内存缓存。
+func WithCache(cache Cache) ConfigOption {
+	return cacheOption{cache}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 下载 URL 图片的客户端配置选项。
+type clientOption struct {
+	client api.Client
+}
+
+func (o clientOption) apply(c *config) error {
+	if o.client == nil {
+		return errors.New("`client` cannot be nil")
+	}
+	c.client = o.client
+	return nil
+}
+
+// 自定义配置下载 URL 图片的客户端，用于计算图片内容摘要作为缓存键，默认为 api.DefaultClient。
+func WithClient(client api.Client) ConfigOption {
+	return clientOption{client}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 本地图片文件上传配置选项。
+type localFilesOption bool
+
+func (o localFilesOption) apply(c *config) error {
+	c.localFiles = bool(o)
+	return nil
+}
+
+// 配置上传以 "file://" 开头的本地图片文件（如 "file:///data/images/banner.png"），默认不上传并返回 ErrLocalFilesDisabled。
+//
+// 注意：开启后上传器会读取服务器上对应路径的文件并上传到极光，请勿对来源不可信的推送请求开启。
+func WithLocalFiles() ConfigOption {
+	return localFilesOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 本地文件上传到小米通道配置选项。
+type xiaomiFileOption bool
+
+func (o xiaomiFileOption) apply(c *config) error {
+	c.xiaomiFile = bool(o)
+	return nil
+}
+
+// 配置本地图片文件同时上传到小米通道，默认仅上传到 OPPO 通道。
+//
+// 注意：小米从 2023.08 开始不再支持推送时动态设置小图标、右侧图标、大图片功能。
+func WithXiaomiFileUpload() ConfigOption {
+	return xiaomiFileOption(true)
+}
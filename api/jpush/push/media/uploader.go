@@ -0,0 +1,382 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/image"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+const (
+	mediaIDPrefix = "jgmedia-" // 极光图片 MediaID 的前缀
+	fileScheme    = "file://"  // 本地图片文件的前缀
+	maxImageSize  = 2 << 20    // 下载或读取图片的大小上限（2 MB），各厂商通道的图片要求均不超过 1 MB
+)
+
+var (
+	ErrLocalFilesDisabled = errors.New("local file upload is disabled, use WithLocalFiles to enable it") // 未开启本地图片文件上传
+	ErrImageTooLarge      = errors.New("image exceeds the size limit of 2 MB")                           // 图片超出大小上限
+)
+
+// # 富媒体通知图片上传器
+//
+// 将推送请求中直接填写的图片 URL 或本地图片文件，通过图片 API 上传并替换为极光 MediaID，处理的字段包括：
+//   - Notification.Android 的 BigPicture、LargeIcon、SmallIcon；
+//   - Options.ThirdPartyChannel 下各厂商的 BigPicture、LargeIcon、SmallIcon。
+//
+// 字段值的处理规则：
+//   - 以 "http://" 或 "https://" 开头：先下载图片计算内容的 SHA-256 摘要，再通过 AddImageByUrl 上传，以「图片类型 + 图片内容的 SHA-256 摘要」作为缓存键；
+//     同时缓存 URL 与 MediaID 的映射，缓存有效期内相同的 URL 不再重复下载（因此不会感知同一 URL 的图片内容变化）；
+//   - 以 "file://" 开头：仅在配置 WithLocalFiles 时，通过 AddImageByFile 将去除前缀后的本地文件上传到 OPPO（可选小米）通道，
+//     以「图片类型 + 文件内容的 SHA-256 摘要」作为缓存键，未配置时返回 ErrLocalFilesDisabled；
+//   - 其他值（如已有的 MediaID、设备上的本地路径、资源名称等）：保持不变，不会读取服务器上的同名文件。
+//
+// 下载或读取的图片超过 2 MB 时返回 ErrImageTooLarge。
+//
+// 字段与图片类型的对应关系：BigPicture → image.BigImage，LargeIcon → image.BigIcon，SmallIcon → image.SmallIcon。
+type Uploader struct {
+	image    image.APIv3
+	cfg      config
+	mu       sync.Mutex
+	inflight map[string]*call
+}
+
+// 正在进行的上传，相同缓存键的并发上传只会请求一次。
+//
+// 上传不受单个调用方 ctx 取消的影响，只有所有等待的调用方都已取消时才会中止。
+type call struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	mediaID string
+	err     error
+}
+
+// 创建新的富媒体通知图片上传器。
+//   - imageAPI：【必填】图片 API v3 接口，推送 API v3 接口（push.APIv3）已包含图片 API v3 接口，可直接传入；
+//   - opts：【可选】上传器配置选项。
+func NewUploader(imageAPI image.APIv3, opts ...ConfigOption) (*Uploader, error) {
+	if imageAPI == nil {
+		return nil, api.ErrNilJPushImageAPIv3
+	}
+
+	c := config{logger: api.DefaultJPushLogger}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+	if c.cache == nil {
+		c.cache = NewMemoryCache(defaultCacheTTL)
+	}
+	if c.client == nil {
+		c.client = api.DefaultClient
+	}
+	return &Uploader{image: imageAPI, cfg: c, inflight: make(map[string]*call)}, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 需要上传的图片字段。
+type field struct {
+	name      string
+	ptr       *string
+	imageType image.Type
+}
+
+// 上传推送请求中的图片 URL 或 "file://" 本地图片文件，并将对应字段替换为极光 MediaID。
+//
+// 只有全部图片上传成功后才会改写 `param`，任一图片上传失败时返回错误，`param` 保持不变。
+func (u *Uploader) Prepare(ctx context.Context, param *push.SendParam) error {
+	if param == nil {
+		return nil
+	}
+
+	fields := collect(param)
+	mediaIDs := make([]string, len(fields))
+	for i, f := range fields {
+		mediaID, err := u.Upload(ctx, *f.ptr, f.imageType)
+		if err != nil {
+			return fmt.Errorf("upload %s %q: %w", f.name, *f.ptr, err)
+		}
+		mediaIDs[i] = mediaID
+	}
+	for i, f := range fields {
+		if *f.ptr != mediaIDs[i] {
+			u.cfg.logger.Debugf(ctx, "图片 %s 已替换：%s -> %s", f.name, *f.ptr, mediaIDs[i])
+			*f.ptr = mediaIDs[i]
+		}
+	}
+	return nil
+}
+
+// 上传单张图片并返回极光 MediaID，`ref` 的处理规则同 Prepare，无需上传时原样返回 `ref`。
+func (u *Uploader) Upload(ctx context.Context, ref string, imageType image.Type) (string, error) {
+	if !imageType.IsValid() {
+		return "", fmt.Errorf("invalid image type %d", imageType)
+	}
+
+	var (
+		key, refKey string
+		upload      func(ctx context.Context) (string, error)
+	)
+	switch {
+	case isURL(ref):
+		refKey = cacheKey(imageType, "ref", digest([]byte(ref)))
+		if mediaID, ok := u.cfg.cache.Get(refKey); ok {
+			u.cfg.logger.Debugf(ctx, "图片 %s 命中缓存：%s", ref, mediaID)
+			return mediaID, nil
+		}
+		data, err := u.download(ctx, ref)
+		if err != nil {
+			return "", err
+		}
+		key = cacheKey(imageType, "url", digest(data))
+		upload = func(ctx context.Context) (string, error) { return u.uploadURL(ctx, ref, imageType) }
+	case isFile(ref):
+		if !u.cfg.localFiles {
+			return "", ErrLocalFilesDisabled
+		}
+		path := strings.TrimPrefix(ref, fileScheme)
+		data, err := readFile(path)
+		if err != nil {
+			return "", err
+		}
+		key = cacheKey(imageType, "file", digest(data))
+		upload = func(ctx context.Context) (string, error) { return u.uploadFile(ctx, path, imageType) }
+	default:
+		return ref, nil
+	}
+
+	mediaID, ok := u.cfg.cache.Get(key)
+	if ok {
+		u.cfg.logger.Debugf(ctx, "图片 %s 命中缓存：%s", ref, mediaID)
+	} else {
+		var err error
+		if mediaID, err = u.do(ctx, key, upload); err != nil {
+			return "", err
+		}
+	}
+	if refKey != "" {
+		u.cfg.cache.Set(refKey, mediaID)
+	}
+	return mediaID, nil
+}
+
+// 合并相同缓存键的并发上传，上传成功后写入缓存。
+//
+// 上传在独立的 goroutine 中执行，调用方 ctx 取消时只停止等待并返回 ctx 的错误，不影响其他等待同一上传的调用方。
+func (u *Uploader) do(ctx context.Context, key string, upload func(ctx context.Context) (string, error)) (string, error) {
+	u.mu.Lock()
+	c, ok := u.inflight[key]
+	if !ok {
+		uploadCtx, cancel := context.WithCancel(detached{ctx})
+		c = &call{done: make(chan struct{}), cancel: cancel}
+		u.inflight[key] = c
+		go u.run(uploadCtx, key, c, upload)
+	}
+	c.waiters++
+	u.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.mediaID, c.err
+	case <-ctx.Done():
+		u.mu.Lock()
+		if c.waiters--; c.waiters == 0 {
+			// 已没有调用方等待，中止上传，后续调用将重新上传
+			c.cancel()
+			if u.inflight[key] == c {
+				delete(u.inflight, key)
+			}
+		}
+		u.mu.Unlock()
+		return "", ctx.Err()
+	}
+}
+
+func (u *Uploader) run(ctx context.Context, key string, c *call, upload func(ctx context.Context) (string, error)) {
+	defer c.cancel()
+	c.mediaID, c.err = upload(ctx)
+	if c.err == nil {
+		u.cfg.cache.Set(key, c.mediaID)
+	}
+
+	u.mu.Lock()
+	if u.inflight[key] == c {
+		delete(u.inflight, key)
+	}
+	u.mu.Unlock()
+	close(c.done)
+}
+
+// 下载 URL 图片的内容，用于计算缓存键。
+func (u *Uploader) download(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.cfg.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return nil, fmt.Errorf("download %q: unexpected status code %d", url, resp.StatusCode)
+	}
+	return readImage(resp.Body)
+}
+
+func (u *Uploader) uploadURL(ctx context.Context, url string, imageType image.Type) (string, error) {
+	result, err := u.image.AddImageByUrl(ctx, &image.AddByUrlParam{ImageType: imageType, ImageUrl: url})
+	if err != nil {
+		return "", err
+	}
+	if err = checkResult(result); err != nil {
+		return "", err
+	}
+	u.cfg.logger.Infof(ctx, "上传图片 %s 成功：%s", url, result.MediaID)
+	return result.MediaID, nil
+}
+
+func (u *Uploader) uploadFile(ctx context.Context, path string, imageType image.Type) (string, error) {
+	param := &image.AddByFileParam{ImageType: imageType, OppoImageFile: path}
+	if u.cfg.xiaomiFile {
+		param.XiaomiImageFile = path
+	}
+	result, err := u.image.AddImageByFile(ctx, param)
+	if err != nil {
+		return "", err
+	}
+	if err = checkResult(result); err != nil {
+		return "", err
+	}
+	u.cfg.logger.Infof(ctx, "上传图片文件 %s 成功：%s", path, result.MediaID)
+	return result.MediaID, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 收集推送请求中需要上传的图片字段。
+func collect(param *push.SendParam) []field {
+	var fields []field
+	add := func(name string, ptr *string, imageType image.Type) {
+		if *ptr != "" && !strings.HasPrefix(*ptr, mediaIDPrefix) && (isURL(*ptr) || isFile(*ptr)) {
+			fields = append(fields, field{name: name, ptr: ptr, imageType: imageType})
+		}
+	}
+
+	if param.Notification != nil && param.Notification.Android != nil {
+		android := param.Notification.Android
+		add("notification.android.big_pic_path", &android.BigPicture, image.BigImage)
+		add("notification.android.large_icon", &android.LargeIcon, image.BigIcon)
+		add("notification.android.small_icon_uri", &android.SmallIcon, image.SmallIcon)
+	}
+
+	if param.Options != nil && param.Options.ThirdPartyChannel != nil {
+		channel := param.Options.ThirdPartyChannel
+		vendors := []struct {
+			name string
+			opts *options.ThirdPartyChannelOptions
+		}{
+			{"xiaomi", channel.Xiaomi}, {"huawei", channel.Huawei}, {"honor", channel.Honor},
+			{"meizu", channel.Meizu}, {"oppo", channel.OPPO}, {"vivo", channel.Vivo},
+			{"fcm", channel.FCM}, {"nio", channel.NIO}, {"asus", channel.ASUS}, {"hmos", channel.HMOS},
+		}
+		for _, v := range vendors {
+			if v.opts == nil {
+				continue
+			}
+			prefix := "options.third_party_channel." + v.name
+			add(prefix+".big_pic_path", &v.opts.BigPicture, image.BigImage)
+			add(prefix+".large_icon", &v.opts.LargeIcon, image.BigIcon)
+			add(prefix+".small_icon_uri", &v.opts.SmallIcon, image.SmallIcon)
+		}
+	}
+	return fields
+}
+
+func isURL(ref string) bool {
+	return strings.HasPrefix(ref, "http://") || strings.HasPrefix(ref, "https://")
+}
+
+func isFile(ref string) bool {
+	return strings.HasPrefix(ref, fileScheme)
+}
+
+// 读取本地图片文件，超过大小上限时返回 ErrImageTooLarge。
+func readFile(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return readImage(f)
+}
+
+// 读取图片内容，最多读取 maxImageSize 字节，超过时返回 ErrImageTooLarge。
+func readImage(r io.Reader) ([]byte, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(r, maxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxImageSize {
+		return nil, ErrImageTooLarge
+	}
+	return data, nil
+}
+
+func cacheKey(imageType image.Type, kind, sum string) string {
+	return fmt.Sprintf("%d:%s:sha256:%s", imageType, kind, sum)
+}
+
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// 保留 ctx 中的值但不继承其取消信号与截止时间，用于多个调用方共享的上传。
+type detached struct {
+	context.Context
+}
+
+func (detached) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detached) Done() <-chan struct{}       { return nil }
+func (detached) Err() error                  { return nil }
+
+func checkResult(result *image.AddByUrlResult) error {
+	if err := api.CheckResponse(result.Response, result.Error); err != nil {
+		return err
+	}
+	if result.MediaID == "" {
+		return errors.New("empty media_id in response")
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiapp
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const defaultConcurrency = 5 // 默认的并发推送数
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 多应用推送器配置。
+type config struct {
+	logger      jiguang.Logger                                // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency int                                           // 并发推送数，默认为 5
+	builderFunc func(b *push.APIv3Builder) *push.APIv3Builder // 通过凭证创建推送 API 时的构建器自定义函数
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 多应用推送器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置多应用推送器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发推送数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时向多少个应用发起推送，默认为 5。
+func WithConcurrency(concurrency int) ConfigOption {
+	return concurrencyOption(concurrency)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 构建器自定义函数配置选项。
+type builderFuncOption func(b *push.APIv3Builder) *push.APIv3Builder
+
+func (o builderFuncOption) apply(c *config) error {
+	if o == nil {
+		return errors.New("`builderFunc` cannot be nil")
+	}
+	c.builderFunc = o
+	return nil
+}
+
+// 自定义通过凭证（NewSenderFromCredentials）创建推送 API 时的构建器，如设置 HTTP 客户端、日志级别、开启 SM2 加密等。
+//
+// 构建器已设置好 AppKey 和 MasterSecret，自定义函数返回用于 Build 的构建器。
+func WithBuilderFunc(fn func(b *push.APIv3Builder) *push.APIv3Builder) ConfigOption {
+	return builderFuncOption(fn)
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multiapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/gpush"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+)
+
+// 推送请求在本地失败（如网络错误、响应解析失败）时，SendError 中使用的错误码。
+const CodeRequestFailed = -1
+
+var ErrNoApps = errors.New("no apps to push") // 没有需要推送的应用
+
+// # 应用凭证
+type Credential struct {
+	Name         string // 【可选】应用名称，用作推送结果的键，默认为 AppKey
+	AppKey       string // 【必填】应用的 AppKey
+	MasterSecret string // 【必填】应用的 MasterSecret
+}
+
+// # 应用
+type App struct {
+	Name string     // 【必填】应用名称，用作推送结果的键，需唯一
+	API  push.APIv3 // 【必填】应用的推送 API v3 接口
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 多应用推送结果
+//
+// 与分组推送结果 gpush.SendResult 的结构一致，以应用名称为键。
+type SendResult struct {
+	Successes map[string]gpush.SendSuccess // 推送成功集合
+	Errors    map[string]gpush.SendError   // 推送失败错误集合，本地请求失败时错误码为 CodeRequestFailed
+	Results   map[string]*push.SendResult  // 各应用的原始推送结果，本地请求失败时为 nil
+}
+
+// 是否所有应用均推送成功。
+func (rs *SendResult) IsSuccess() bool {
+	return rs != nil && len(rs.Errors) == 0
+}
+
+// 推送失败的应用名称列表（已排序）。
+func (rs *SendResult) Failed() []string {
+	if rs == nil {
+		return nil
+	}
+	names := make([]string, 0, len(rs.Errors))
+	for name := range rs.Errors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 多应用推送器
+//
+// 将同一个推送请求并发推送给多个未在极光控制台分组的应用（如分属不同账号的品牌应用），效果类似于分组推送（gpush）。
+//
+// 注意：
+//   - 推送请求会浅拷贝后分别发送给各应用，CID 与应用相关，会被清空；
+//   - Registration ID、Live Activity ID 等与应用相关的推送目标通常只对其中一个应用有效，建议使用广播、标签或别名等推送目标。
+type Sender struct {
+	apps []App
+	cfg  config
+}
+
+// 创建新的多应用推送器。
+//   - apps：【必填】需要推送的应用列表，应用名称需唯一；
+//   - opts：【可选】推送器配置选项。
+func NewSender(apps []App, opts ...ConfigOption) (*Sender, error) {
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	return newSender(apps, c)
+}
+
+// 通过应用凭证创建新的多应用推送器，可通过 WithBuilderFunc 自定义各应用推送 API 的构建器。
+func NewSenderFromCredentials(creds []Credential, opts ...ConfigOption) (*Sender, error) {
+	c, err := newConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := make([]App, 0, len(creds))
+	for _, cred := range creds {
+		name := cred.Name
+		if name == "" {
+			name = cred.AppKey
+		}
+		b := push.NewAPIv3Builder().SetAppKey(cred.AppKey).SetMasterSecret(cred.MasterSecret)
+		if c.builderFunc != nil {
+			b = c.builderFunc(b)
+		}
+		pushAPI, err := b.Build()
+		if err != nil {
+			return nil, fmt.Errorf("app %q: %w", name, err)
+		}
+		apps = append(apps, App{Name: name, API: pushAPI})
+	}
+	return newSender(apps, c)
+}
+
+func newConfig(opts []ConfigOption) (config, error) {
+	c := config{logger: api.DefaultJPushLogger, concurrency: defaultConcurrency}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return c, err
+		}
+	}
+	return c, nil
+}
+
+func newSender(apps []App, c config) (*Sender, error) {
+	if len(apps) == 0 {
+		return nil, ErrNoApps
+	}
+	names := make(map[string]struct{}, len(apps))
+	for _, app := range apps {
+		if app.Name == "" {
+			return nil, errors.New("app name cannot be empty")
+		}
+		if app.API == nil {
+			return nil, fmt.Errorf("app %q: %w", app.Name, api.ErrNilJPushPushAPIv3)
+		}
+		if _, ok := names[app.Name]; ok {
+			return nil, fmt.Errorf("duplicate app name %q", app.Name)
+		}
+		names[app.Name] = struct{}{}
+	}
+	return &Sender{apps: append([]App(nil), apps...), cfg: c}, nil
+}
+
+// 应用名称列表。
+func (s *Sender) Apps() []string {
+	names := make([]string, len(s.apps))
+	for i, app := range s.apps {
+		names[i] = app.Name
+	}
+	return names
+}
+
+// 将推送请求并发推送给所有应用，各应用的推送结果（成功或失败）汇总在返回结果中。
+func (s *Sender) Send(ctx context.Context, param *push.SendParam) (*SendResult, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+
+	result := &SendResult{
+		Successes: make(map[string]gpush.SendSuccess),
+		Errors:    make(map[string]gpush.SendError),
+		Results:   make(map[string]*push.SendResult),
+	}
+
+	var mu sync.Mutex
+	api.RunConcurrently(len(s.apps), s.cfg.concurrency, func(i int) {
+		app := s.apps[i]
+		p := *param
+		p.CID = ""
+		rs, err := app.API.Send(ctx, &p)
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			s.cfg.logger.Errorf(ctx, "推送到应用 %s 失败：%s", app.Name, err)
+			result.Errors[app.Name] = gpush.SendError{CodeError: api.CodeError{Code: CodeRequestFailed, Message: err.Error()}}
+			return
+		}
+
+		result.Results[app.Name] = rs
+		if err = api.CheckResponse(rs.Response, rs.Error); err != nil {
+			sendErr := gpush.SendError{CodeError: api.CodeError{Code: CodeRequestFailed, Message: err.Error()}}
+			var codeErr *api.CodeError
+			if errors.As(err, &codeErr) {
+				sendErr.CodeError = *codeErr
+			}
+			s.cfg.logger.Warnf(ctx, "推送到应用 %s 失败：%s", app.Name, sendErr.Error())
+			result.Errors[app.Name] = sendErr
+			return
+		}
+		result.Successes[app.Name] = gpush.SendSuccess{MsgID: rs.MsgID, SendNo: rs.SendNo}
+		s.cfg.logger.Debugf(ctx, "推送到应用 %s 成功，消息 ID：%s", app.Name, rs.MsgID)
+	})
+	return result, nil
+}
@@ -87,7 +87,8 @@ type Android struct {
 	// 【可选】文本条目通知栏样式。
 	//  - 当 Style = style.Inbox 时可用，JSON 的每个 key 对应的 value 会被当作文本条目逐条展示；
 	//  - 若没有填充 [厂商 Inbox]，则默认使用该 Inbox 字段展示；
-	//  - 支持 API 16 以上的 ROM。
+	//  - 支持 API 16 以上的 ROM；
+	//  - 推荐使用 SetInbox 设置。
 	// [厂商 Inbox]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push#third_party_channel-%E8%AF%B4%E6%98%8E
 	Inbox map[string]interface{} `json:"inbox,omitempty"`
 	// 【可选】大图片通知栏样式。
@@ -112,6 +113,7 @@ type Android struct {
 	//  此时调用极光 API 推送通知时，可使用此字段传入不超过 100 字符的通知内容作为 vivo 通道通知内容；
 	//  - mzpns_content_forshort：【可选】魅族通知内容。由于魅族官方的通知内容长度限制为 100 个字符以内（中英文都算一个），当通知内容（极光的 Alert 字段的值）长度超过 100 时，魅族通道会推送失败。
 	//  此时调用极光 API 推送通知时，可使用此字段传入不超过 100 字符的通知内容作为魅族通道通知内容。
+	// 推荐使用 SetExtras 设置类型化的 AndroidExtras，以校验各厂商通知内容的长度限制。
 	// [厂商通道无法跳转问题分析]: https://docs.jiguang.cn/jpush/faq/tech_faq#%E5%8E%82%E5%95%86%E9%80%9A%E9%81%93%E6%97%A0%E6%B3%95%E8%B7%B3%E8%BD%AC%EF%BC%9F
 	Extras map[string]interface{} `json:"extras,omitempty"`
 	// 【可选】通知栏大图标。
@@ -0,0 +1,128 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/style"
+)
+
+// 厂商通知内容的长度限制。
+const (
+	maxXiaomiShortContent = 128 // 小米：128 个字符（中英文都算一个）
+	maxOPPOShortContent   = 200 // OPPO：200 个字符（中英文都算一个）
+	maxVivoShortContent   = 100 // vivo：100 个字符（1 个汉字等于 2 个英文字符）
+	maxMeizuShortContent  = 100 // 魅族：100 个字符（中英文都算一个）
+)
+
+// # Android 通知扩展字段
+//
+// 类型化的 Android.Extras，可通过 Android.SetExtras 校验并设置；如需传递本结构未涵盖的字段，仍可直接填充 Android.Extras。
+type AndroidExtras struct {
+	// 【可选】业务自定义的 key/value 信息，key 不能与下面的保留字段重名。
+	Values map[string]interface{}
+	// 【可选】小米通道通知内容，对应 mipns_content_forshort，不超过 128 个字符。
+	XiaomiShortContent string
+	// 【可选】OPPO 通道通知内容，对应 oppns_content_forshort，不超过 200 个字符。
+	OPPOShortContent string
+	// 【可选】vivo 通道通知内容，对应 vpns_content_forshort，不超过 100 个字符（1 个汉字等于 2 个英文字符）。
+	VivoShortContent string
+	// 【可选】魅族通道通知内容，对应 mzpns_content_forshort，不超过 100 个字符。
+	MeizuShortContent string
+}
+
+var reservedExtrasKeys = [...]string{
+	"mipns_content_forshort",
+	"oppns_content_forshort",
+	"vpns_content_forshort",
+	"mzpns_content_forshort",
+}
+
+// 校验 Android 通知扩展字段。
+func (e *AndroidExtras) Validate() error {
+	if e == nil {
+		return errors.New("`extras` cannot be nil")
+	}
+	for _, key := range reservedExtrasKeys {
+		if _, ok := e.Values[key]; ok {
+			return fmt.Errorf("extras key %q is reserved, use the typed field instead", key)
+		}
+	}
+	if n := utf8.RuneCountInString(e.XiaomiShortContent); n > maxXiaomiShortContent {
+		return fmt.Errorf("xiaomi short content too long: %d > %d", n, maxXiaomiShortContent)
+	}
+	if n := utf8.RuneCountInString(e.OPPOShortContent); n > maxOPPOShortContent {
+		return fmt.Errorf("oppo short content too long: %d > %d", n, maxOPPOShortContent)
+	}
+	if n := vivoLength(e.VivoShortContent); n > maxVivoShortContent {
+		return fmt.Errorf("vivo short content too long: %d > %d", n, maxVivoShortContent)
+	}
+	if n := utf8.RuneCountInString(e.MeizuShortContent); n > maxMeizuShortContent {
+		return fmt.Errorf("meizu short content too long: %d > %d", n, maxMeizuShortContent)
+	}
+	return nil
+}
+
+// 转换为 Android.Extras 字段的 JSON 对象格式。
+func (e *AndroidExtras) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(e.Values)+len(reservedExtrasKeys))
+	for k, v := range e.Values {
+		m[k] = v
+	}
+	for i, v := range [...]string{e.XiaomiShortContent, e.OPPOShortContent, e.VivoShortContent, e.MeizuShortContent} {
+		if v != "" {
+			m[reservedExtrasKeys[i]] = v
+		}
+	}
+	return m
+}
+
+// vivo 的字符长度：1 个汉字（非 ASCII 字符）等于 2 个英文字符。
+func vivoLength(s string) int {
+	n := 0
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			n++
+		} else {
+			n += 2
+		}
+	}
+	return n
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 校验并设置扩展字段，会覆盖 Extras 字段中已有的内容。
+func (a *Android) SetExtras(e *AndroidExtras) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	a.Extras = e.Map()
+	return nil
+}
+
+// 校验并设置文本条目通知栏样式的条目，会覆盖 Inbox 字段中已有的内容，同时将 Style 设置为 style.Inbox。
+func (a *Android) SetInbox(lines ...string) error {
+	inbox := style.InboxLines(lines)
+	if err := inbox.Validate(0); err != nil {
+		return err
+	}
+	a.Inbox = inbox.Map()
+	a.Style = style.Inbox
+	return nil
+}
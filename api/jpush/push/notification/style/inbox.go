@@ -0,0 +1,52 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package style
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// # 文本条目通知栏样式（Inbox）的条目列表
+//
+// 按顺序逐条展示的文本条目，序列化为 `{"inbox1": "...", "inbox2": "...", ...}` 格式的 Inbox 字段。
+type InboxLines []string
+
+// 校验文本条目列表：至少 1 条，每条不能为空；`max` 大于 0 时，条目数不能超过 `max`。
+func (l InboxLines) Validate(max int) error {
+	if len(l) == 0 {
+		return errors.New("inbox lines cannot be empty")
+	}
+	if max > 0 && len(l) > max {
+		return fmt.Errorf("too many inbox lines: %d > %d", len(l), max)
+	}
+	for i, line := range l {
+		if strings.TrimSpace(line) == "" {
+			return fmt.Errorf("inbox line %d cannot be blank", i+1)
+		}
+	}
+	return nil
+}
+
+// 转换为 Inbox 字段的 JSON 对象格式。
+func (l InboxLines) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(l))
+	for i, line := range l {
+		m["inbox"+strconv.Itoa(i+1)] = line
+	}
+	return m
+}
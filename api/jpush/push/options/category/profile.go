@@ -0,0 +1,277 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package category
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+// 消息类型分类（对应 Options.Classification）。
+const (
+	ClassificationOperation = 0 // 运营消息
+	ClassificationSystem    = 1 // 系统消息
+)
+
+// 内置的消息类别名称。
+const (
+	IM        = "im"        // 即时聊天
+	Account   = "account"   // 账号与安全
+	Order     = "order"     // 订单与物流更新
+	Marketing = "marketing" // 营销活动
+)
+
+// # 消息类别
+//
+// 一个消息类别（如 “即时聊天”、“订单更新”、“营销活动”）描述了同一类业务消息在各个 Android 厂商通道上应当使用的分类参数，
+// 通过 Apply 可以将其展开为 Options.Classification 以及 ThirdPartyChannel 中对应厂商的 ThirdPartyChannelOptions 字段。
+//
+// 厂商通道的 ChannelID 需要开发者自行向厂商申请，因此内置的消息类别不包含 ChannelID，请通过 Register 注册包含自己 ChannelID 的消息类别。
+type Profile struct {
+	// 【必填】消息类别名称。
+	Name string
+	// 【可选】消息类别描述。
+	Description string
+	// 【必填】消息类型分类，ClassificationOperation（0）或 ClassificationSystem（1），展开为 Options.Classification。
+	Classification *int
+	// 【可选】是否要求启用情景商业 Push（Options.MktEnable = true）。
+	RequireMktEnable bool
+	// 【可选】小米通道参数。
+	Xiaomi *VendorProfile
+	// 【可选】华为通道参数。
+	Huawei *VendorProfile
+	// 【可选】荣耀通道参数。
+	Honor *VendorProfile
+	// 【可选】OPPO 通道参数。
+	OPPO *VendorProfile
+	// 【可选】vivo 通道参数。
+	Vivo *VendorProfile
+	// 【可选】蔚来通道参数。
+	NIO *VendorProfile
+}
+
+// # 厂商通道分类参数
+//
+// 字段含义同 options.ThirdPartyChannelOptions 中的同名字段，为空的字段不会被展开。
+type VendorProfile struct {
+	ChannelID      string // 通知栏消息分类（小米、华为、OPPO、蔚来）
+	Importance     string // 通知栏消息智能分类（华为、荣耀），LOW / NORMAL / HIGH
+	Category       string // 厂商消息场景标识（华为、vivo、OPPO）
+	Classification *int   // 通知栏消息分类（vivo）
+	NotifyLevel    int    // 通知栏消息提醒等级（OPPO），1 / 2 / 16
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+var (
+	mu       sync.RWMutex
+	profiles = make(map[string]*Profile)
+)
+
+func init() {
+	system, operation := ClassificationSystem, ClassificationOperation
+	for _, p := range []*Profile{
+		{
+			Name:           IM,
+			Description:    "即时聊天：单聊、群聊等用户间消息",
+			Classification: &system,
+			Huawei:         &VendorProfile{Importance: "NORMAL", Category: "IM"},
+			Honor:          &VendorProfile{Importance: "NORMAL"},
+			OPPO:           &VendorProfile{Category: "IM", NotifyLevel: 16},
+			Vivo:           &VendorProfile{Classification: &system, Category: "IM"},
+		},
+		{
+			Name:           Account,
+			Description:    "账号与安全：登录验证、账号变动等提醒",
+			Classification: &system,
+			Huawei:         &VendorProfile{Importance: "NORMAL", Category: "ACCOUNT"},
+			Honor:          &VendorProfile{Importance: "NORMAL"},
+			OPPO:           &VendorProfile{Category: "ACCOUNT", NotifyLevel: 2},
+			Vivo:           &VendorProfile{Classification: &system, Category: "ACCOUNT"},
+		},
+		{
+			Name:           Order,
+			Description:    "订单与物流更新：下单、支付、发货、配送等状态变更",
+			Classification: &system,
+			Huawei:         &VendorProfile{Importance: "NORMAL", Category: "EXPRESS"},
+			Honor:          &VendorProfile{Importance: "NORMAL"},
+			OPPO:           &VendorProfile{Category: "ORDER", NotifyLevel: 2},
+			Vivo:           &VendorProfile{Classification: &system, Category: "ORDER"},
+		},
+		{
+			Name:             Marketing,
+			Description:      "营销活动：促销、优惠券、活动推荐等运营内容",
+			Classification:   &operation,
+			RequireMktEnable: true,
+			Huawei:           &VendorProfile{Importance: "LOW", Category: "MARKETING"},
+			Honor:            &VendorProfile{Importance: "LOW"},
+			OPPO:             &VendorProfile{Category: "MARKETING"},
+			Vivo:             &VendorProfile{Classification: &operation, Category: "MARKETING"},
+		},
+	} {
+		profiles[p.Name] = p
+	}
+}
+
+// 注册消息类别，若已存在同名的消息类别（包括内置的消息类别），则会被替换。
+func Register(p *Profile) error {
+	if p == nil {
+		return errors.New("`profile` cannot be nil")
+	}
+	if err := p.validate(); err != nil {
+		return err
+	}
+	cp := p.clone()
+	mu.Lock()
+	defer mu.Unlock()
+	profiles[cp.Name] = cp
+	return nil
+}
+
+// 获取指定名称的消息类别（副本），可在其基础上修改后以新的名称注册。
+func Lookup(name string) (*Profile, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := profiles[name]
+	if !ok {
+		return nil, false
+	}
+	return p.clone(), true
+}
+
+// 获取所有已注册的消息类别名称，按字母顺序排列。
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// 将指定名称的消息类别展开到推送可选项 `opts` 中，详见 Profile.Apply。
+func Apply(opts *options.Options, name string) error {
+	mu.RLock()
+	p, ok := profiles[name]
+	mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("unknown message category %q", name)
+	}
+	return p.Apply(opts)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 展开消息类别
+//
+// 将消息类别展开为 `opts` 的 Classification 以及 ThirdPartyChannel 中对应厂商的分类参数：
+//   - 已显式设置的字段不会被覆盖，即调用方的设置优先于消息类别；
+//   - 展开后会调用 Validate 校验各厂商的分类规则，校验不通过时返回错误（`opts` 已被修改）。
+func (p *Profile) Apply(opts *options.Options) error {
+	if p == nil {
+		return errors.New("`profile` cannot be nil")
+	}
+	if opts == nil {
+		return errors.New("`opts` cannot be nil")
+	}
+
+	if p.RequireMktEnable && (opts.MktEnable == nil || !*opts.MktEnable) {
+		return fmt.Errorf("message category %q requires `MktEnable` to be true", p.Name)
+	}
+
+	if opts.Classification == nil && p.Classification != nil {
+		c := *p.Classification
+		opts.Classification = &c
+	}
+
+	if p.Xiaomi != nil || p.Huawei != nil || p.Honor != nil || p.OPPO != nil || p.Vivo != nil || p.NIO != nil {
+		if opts.ThirdPartyChannel == nil {
+			opts.ThirdPartyChannel = &options.ThirdPartyChannel{}
+		}
+		tpc := opts.ThirdPartyChannel
+		p.Xiaomi.expand(&tpc.Xiaomi)
+		p.Huawei.expand(&tpc.Huawei)
+		p.Honor.expand(&tpc.Honor)
+		p.OPPO.expand(&tpc.OPPO)
+		p.Vivo.expand(&tpc.Vivo)
+		p.NIO.expand(&tpc.NIO)
+	}
+
+	return Validate(opts)
+}
+
+func (vp *VendorProfile) expand(target **options.ThirdPartyChannelOptions) {
+	if vp == nil {
+		return
+	}
+	if *target == nil {
+		*target = &options.ThirdPartyChannelOptions{}
+	}
+	o := *target
+	if o.ChannelID == "" {
+		o.ChannelID = vp.ChannelID
+	}
+	if o.Importance == "" {
+		o.Importance = vp.Importance
+	}
+	if o.Category == "" {
+		o.Category = vp.Category
+	}
+	if o.Classification == nil && vp.Classification != nil {
+		c := *vp.Classification
+		o.Classification = &c
+	}
+	if o.NotifyLevel == 0 {
+		o.NotifyLevel = vp.NotifyLevel
+	}
+}
+
+func (p *Profile) validate() error {
+	if p.Name == "" {
+		return errors.New("`profile.Name` cannot be empty")
+	}
+	if p.Classification == nil {
+		return errors.New("`profile.Classification` cannot be nil")
+	}
+	if c := *p.Classification; c != ClassificationOperation && c != ClassificationSystem {
+		return fmt.Errorf("invalid `profile.Classification` %d, must be 0 or 1", c)
+	}
+	return nil
+}
+
+func (p *Profile) clone() *Profile {
+	cp := *p
+	if p.Classification != nil {
+		c := *p.Classification
+		cp.Classification = &c
+	}
+	for _, vp := range []**VendorProfile{&cp.Xiaomi, &cp.Huawei, &cp.Honor, &cp.OPPO, &cp.Vivo, &cp.NIO} {
+		if *vp != nil {
+			v := **vp
+			if v.Classification != nil {
+				c := *v.Classification
+				v.Classification = &c
+			}
+			*vp = &v
+		}
+	}
+	return &cp
+}
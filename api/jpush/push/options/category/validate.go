@@ -0,0 +1,154 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package category
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+// vivo、OPPO 的运营消息场景标识，其余场景标识（如 IM、ACCOUNT、ORDER 等）为系统消息。
+var operationCategories = map[string]bool{
+	"MARKETING": true,
+	"NEWS":      true,
+	"CONTENT":   true,
+	"SOCIAL":    true,
+}
+
+// # 校验厂商分类规则
+//
+// 校验推送可选项 `opts` 中的消息分类参数是否符合各厂商通道的规则，返回第一个不符合规则的错误：
+//   - Options.Classification 和 vivo Classification 只能为 0 或 1；
+//   - 华为 Importance 只能为 LOW / NORMAL / HIGH，荣耀 Importance 只能为 LOW / NORMAL；运营消息的华为 Importance 必须为 LOW；
+//   - 荣耀运营消息不支持携带图标（LargeIcon / SmallIcon）；
+//   - OPPO NotifyLevel 只能为 1 / 2 / 16，且必须同时设置 Category，仅对非运营类场景生效；
+//   - vivo、OPPO 的 Category 必须与消息类型分类一致（系统消息不能使用 MARKETING、NEWS 等运营类场景标识，反之亦然）；
+//   - 小米 MiTemplateID 必须同时设置 ChannelID；
+//   - 厂商 VoIP、实况窗、超级岛、原子通知、透传等特殊推送类型必须设置 Options.Classification = 1（OPPO 仅 VoIP 消息要求，实况窗通知不要求）。
+func Validate(opts *options.Options) error {
+	if opts == nil {
+		return nil
+	}
+
+	classification := -1 // 未设置
+	if opts.Classification != nil {
+		classification = *opts.Classification
+		if classification != ClassificationOperation && classification != ClassificationSystem {
+			return fmt.Errorf("invalid `Classification` %d, must be 0 or 1", classification)
+		}
+	}
+
+	tpc := opts.ThirdPartyChannel
+	if tpc == nil {
+		return nil
+	}
+
+	if o := tpc.Huawei; o != nil {
+		switch o.Importance {
+		case "", "LOW", "NORMAL", "HIGH":
+		default:
+			return fmt.Errorf("invalid huawei `Importance` %q, must be LOW, NORMAL or HIGH", o.Importance)
+		}
+		if o.Importance != "" && o.Importance != "LOW" &&
+			(classification == ClassificationOperation || o.Category == "MARKETING") {
+			return fmt.Errorf("huawei `Importance` must be LOW for operation messages, got %q", o.Importance)
+		}
+		if o.HwPushType != nil && *o.HwPushType == 7 && classification != ClassificationSystem {
+			return fmt.Errorf("huawei `HwPushType` %d requires `Classification` to be 1", *o.HwPushType)
+		}
+	}
+
+	if o := tpc.Honor; o != nil {
+		switch o.Importance {
+		case "", "LOW", "NORMAL":
+		default:
+			return fmt.Errorf("invalid honor `Importance` %q, must be LOW or NORMAL", o.Importance)
+		}
+		if classification == ClassificationOperation && (o.LargeIcon != "" || o.SmallIcon != "") {
+			return errors.New("honor does not support icons for operation messages")
+		}
+		if o.HonorPushType != nil && classification != ClassificationSystem {
+			return fmt.Errorf("honor `HonorPushType` %d requires `Classification` to be 1", *o.HonorPushType)
+		}
+	}
+
+	if o := tpc.OPPO; o != nil {
+		switch o.NotifyLevel {
+		case 0, 1, 2, 16:
+		default:
+			return fmt.Errorf("invalid oppo `NotifyLevel` %d, must be 1, 2 or 16", o.NotifyLevel)
+		}
+		if o.NotifyLevel != 0 {
+			if o.Category == "" {
+				return errors.New("oppo `NotifyLevel` requires `Category`")
+			}
+			if operationCategories[o.Category] {
+				return fmt.Errorf("oppo `NotifyLevel` does not apply to operation category %q", o.Category)
+			}
+		}
+		if err := checkCategory("oppo", o.Category, classification); err != nil {
+			return err
+		}
+		if o.OpPushType != nil && *o.OpPushType == 3 && classification != ClassificationSystem { // 仅 VoIP 消息要求系统消息，实况窗通知（7）不要求
+			return fmt.Errorf("oppo `OpPushType` %d requires `Classification` to be 1", *o.OpPushType)
+		}
+	}
+
+	if o := tpc.Vivo; o != nil {
+		c := classification
+		if o.Classification != nil {
+			if v := *o.Classification; v != ClassificationOperation && v != ClassificationSystem {
+				return fmt.Errorf("invalid vivo `Classification` %d, must be 0 or 1", v)
+			}
+			if c == -1 { // Options.Classification 优先级更高
+				c = *o.Classification
+			}
+		}
+		if err := checkCategory("vivo", o.Category, c); err != nil {
+			return err
+		}
+		if (o.VivoPushType != nil || o.VivoInAppMsg != nil || o.VivoLiveMessage != nil) && classification != ClassificationSystem {
+			return errors.New("vivo special push types require `Classification` to be 1")
+		}
+	}
+
+	if o := tpc.Xiaomi; o != nil {
+		if o.MiTemplateID != "" && o.ChannelID == "" {
+			return errors.New("xiaomi `MiTemplateID` requires `ChannelID`")
+		}
+		if (o.MiPushType != nil || o.MiLivePayload != nil) && classification != ClassificationSystem {
+			return errors.New("xiaomi special push types require `Classification` to be 1")
+		}
+	}
+
+	return nil
+}
+
+// 校验厂商场景标识与消息类型分类是否一致。
+func checkCategory(vendor, category string, classification int) error {
+	if category == "" || classification == -1 {
+		return nil
+	}
+	isOperation := operationCategories[category]
+	if classification == ClassificationSystem && isOperation {
+		return fmt.Errorf("%s `Category` %q is an operation category, but `Classification` is 1", vendor, category)
+	}
+	if classification == ClassificationOperation && !isOperation {
+		return fmt.Errorf("%s `Category` %q is a system category, but `Classification` is 0", vendor, category)
+	}
+	return nil
+}
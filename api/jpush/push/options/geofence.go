@@ -0,0 +1,110 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"time"
+)
+
+// # 地理坐标
+type GeoPoint struct {
+	Longitude float64 `json:"longitude"` // 【必填】经度，取值范围为 [-180, 180]。
+	Latitude  float64 `json:"latitude"`  // 【必填】纬度，取值范围为 [-90, 90]。
+}
+
+// # 地理围栏配置参数
+//
+// 类型化的 Options.Geofence，可通过 Options.SetGeofence 校验并设置；如需传递本结构未涵盖的字段，仍可直接填充 Options.Geofence。
+type Geofence struct {
+	// 【必填】围栏中心点坐标。
+	Center GeoPoint `json:"center"`
+	// 【必填】围栏半径，单位为米，必须大于 0。
+	Radius int `json:"radius"`
+	// 【可选】是否重复触发，默认为 false，即设备进入围栏后仅触发一次。
+	Repeat bool `json:"repeat"`
+	// 【可选】围栏有效时长，精确到秒，为 0 时表示不设置（以极光服务端默认值为准）；序列化为 JSON 时以秒为单位。
+	Duration time.Duration `json:"-"`
+}
+
+// 校验地理围栏配置参数。
+func (g *Geofence) Validate() error {
+	if g == nil {
+		return errors.New("`geofence` cannot be nil")
+	}
+	if math.IsNaN(g.Center.Longitude) || g.Center.Longitude < -180 || g.Center.Longitude > 180 {
+		return errors.New("geofence center longitude must be between -180 and 180")
+	}
+	if math.IsNaN(g.Center.Latitude) || g.Center.Latitude < -90 || g.Center.Latitude > 90 {
+		return errors.New("geofence center latitude must be between -90 and 90")
+	}
+	if g.Radius <= 0 {
+		return errors.New("geofence radius must be positive")
+	}
+	if g.Duration < 0 {
+		return errors.New("geofence duration cannot be negative")
+	}
+	if g.Duration%time.Second != 0 {
+		return errors.New("geofence duration must be a whole number of seconds")
+	}
+	return nil
+}
+
+// 转换为 Options.Geofence 字段的 JSON 对象格式：
+//
+//	{"center": {"longitude": 116.4, "latitude": 39.9}, "radius": 500, "repeat": false, "duration": 3600}
+func (g *Geofence) Map() map[string]interface{} {
+	m := map[string]interface{}{
+		"center": map[string]interface{}{
+			"longitude": g.Center.Longitude,
+			"latitude":  g.Center.Latitude,
+		},
+		"radius": g.Radius,
+		"repeat": g.Repeat,
+	}
+	if g.Duration > 0 {
+		m["duration"] = int64(g.Duration / time.Second)
+	}
+	return m
+}
+
+func (g Geofence) MarshalJSON() ([]byte, error) {
+	return json.Marshal(g.Map())
+}
+
+func (g *Geofence) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Center   GeoPoint `json:"center"`
+		Radius   int      `json:"radius"`
+		Repeat   bool     `json:"repeat"`
+		Duration int64    `json:"duration"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*g = Geofence{Center: v.Center, Radius: v.Radius, Repeat: v.Repeat, Duration: time.Duration(v.Duration) * time.Second}
+	return nil
+}
+
+// 校验并设置地理围栏配置参数，会覆盖 Geofence 字段中已有的内容。
+func (o *Options) SetGeofence(g *Geofence) error {
+	if err := g.Validate(); err != nil {
+		return err
+	}
+	o.Geofence = g.Map()
+	return nil
+}
@@ -14,7 +14,11 @@
 
 package options
 
-import "github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/style"
+import (
+	"encoding/json"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/style"
+)
 
 // # 推送可选项
 //
@@ -130,6 +134,7 @@ type Options struct {
 	// 【可选】是否设置个性化文案。
 	AlternateSet *bool `json:"alternate_set,omitempty"`
 	// 【可选】地理围栏配置参数。
+	//  - 推荐使用 SetGeofence 设置类型化的 Geofence，以避免 JSON 结构错误。
 	Geofence map[string]interface{} `json:"geofence,omitempty"`
 	// 【可选】极光 WebPortal 的附加属性。
 	PortalExtra *PortalExtraOptions `json:"portal_extra,omitempty"`
@@ -368,7 +373,7 @@ type ThirdPartyChannelOptions struct {
 	//  - 优先使用厂商字段，如果厂商字段没有填充，则使用 Android 里面定义 Inbox 字段，配合华为 Style 使用；
 	//  - JPush Android SDK v3.9.0 版本以上才支持该字段。
 	//
-	// 特别说明：实际展示效果以终端设备为准，由设备系统决定。
+	// 特别说明：实际展示效果以终端设备为准，由设备系统决定。推荐使用 SetInbox 设置。
 	Inbox map[string]interface{} `json:"inbox,omitempty"`
 	// 【可选】厂商消息大图片样式。
 	//  - 为了适配厂商的消息大图片样式，目前支持 OPPO 厂商，使用详情参见 [设置大图片文档]；
@@ -394,8 +399,15 @@ type ThirdPartyChannelOptions struct {
 	// [华为]: https://developer.huawei.com/consumer/cn/doc/development/HMSCore-Guides/android-3rd-party-review-0000001050166008
 	// [OPPO]: https://open.oppomobile.com/new/developmentDoc/info?id=11344
 	// [vivo]: https://dev.vivo.com.cn/documentCenter/doc/585
+	//
+	// 推荐使用 ParseAuditResponse 解析审核结果后，通过 SetAuditResponse 设置，以原样透传原始响应内容（字段顺序、数值精度等）。
+	//
 	// [tuibian.mobileservice.cn]: https://tuibian.mobileservice.cn/
 	AuditResponse map[string]interface{} `json:"auditResponse,omitempty"`
+	// 【可选】第三方审核结果的原始响应内容，不为空时序列化为 auditResponse 字段并忽略 AuditResponse。
+	//
+	// 由 SetAuditResponse 设置，也可以直接填充推必安信息审核 API 的原始响应内容。
+	AuditResponseRaw json.RawMessage `json:"-"`
 	// 【可选】私信模板 ID。2025.07.14 新增。
 	//
 	// 仅支持 OPPO 厂商。
@@ -412,7 +424,7 @@ type ThirdPartyChannelOptions struct {
 	//
 	// 例：私信模板 ID 标题模板为：`欢迎来到 ${city}$，${city}$ 欢迎您`，此参数内容为：`{"city": "北京"}`。
 	//
-	// 详见：[OPUSH 私信模版校验能力接入说明]
+	// 详见：[OPUSH 私信模版校验能力接入说明]，推荐使用 SetPrivateTitleParameters 按模板校验后设置。
 	//
 	// [OPUSH 私信模版校验能力接入说明]: https://open.oppomobile.com/documentation/page/info?id=12391
 	PrivateTitleParameters map[string]interface{} `json:"private_title_parameters,omitempty"`
@@ -422,7 +434,7 @@ type ThirdPartyChannelOptions struct {
 	//
 	// 例：私信模板 ID 对应的内容模板为：`欢迎 ${userName}$ 来到 ${city}$`，此参数内容为：`{"userName": "汤姆", "city": "深圳市"}`。
 	//
-	// 详见：[OPUSH 私信模版校验能力接入说明]
+	// 详见：[OPUSH 私信模版校验能力接入说明]，推荐使用 SetPrivateContentParameters 按模板校验后设置。
 	//
 	// [OPUSH 私信模版校验能力接入说明]: https://open.oppomobile.com/documentation/page/info?id=12391
 	PrivateContentParameters map[string]interface{} `json:"private_content_parameters,omitempty"`
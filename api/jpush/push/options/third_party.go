@@ -0,0 +1,175 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/notification/style"
+)
+
+// 华为厂商 Inbox 样式最多支持的文本条目数。
+const maxHuaweiInboxLines = 5
+
+// 私信模板中的占位符，如 `${city}$`。
+var templatePlaceholder = regexp.MustCompile(`\$\{(\w+)\}\$`)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 校验并设置厂商消息 Inbox 样式的文本条目，会覆盖 Inbox 字段中已有的内容，同时将 Style 设置为 style.Inbox。
+//
+// 目前仅华为厂商支持，最多 5 条。
+func (o *ThirdPartyChannelOptions) SetInbox(lines ...string) error {
+	inbox := style.InboxLines(lines)
+	if err := inbox.Validate(maxHuaweiInboxLines); err != nil {
+		return err
+	}
+	o.Inbox = inbox.Map()
+	o.Style = style.Inbox
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 第三方审核结果
+//
+// 推必安信息审核 API 的原始响应内容。各厂商（华为 / OPPO / vivo）会校验审核结果的签名，因此必须原样透传，不能增删或改写任何字段，
+// 也不能改变数值的精度；本类型在解析时保留原始数值文本，序列化后与原始响应内容等价。
+type AuditResponse struct {
+	raw json.RawMessage
+}
+
+// 解析推必安信息审核 API 的原始响应内容，必须为非空的 JSON 对象。
+func ParseAuditResponse(data []byte) (*AuditResponse, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return nil, errors.New("audit response must be a JSON object")
+	}
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("invalid audit response: %w", err)
+	}
+	if len(m) == 0 {
+		return nil, errors.New("audit response cannot be empty")
+	}
+	return &AuditResponse{raw: append(json.RawMessage(nil), data...)}, nil
+}
+
+// 原始响应内容。
+func (r *AuditResponse) Raw() json.RawMessage {
+	return r.raw
+}
+
+// 转换为 AuditResponse 字段的 JSON 对象格式，数值以 json.Number 保留原始文本。
+func (r *AuditResponse) Map() map[string]interface{} {
+	var m map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(r.raw))
+	dec.UseNumber()
+	_ = dec.Decode(&m) // 已在 ParseAuditResponse 中校验
+	return m
+}
+
+func (r AuditResponse) MarshalJSON() ([]byte, error) {
+	if len(r.raw) == 0 {
+		return []byte("null"), nil
+	}
+	return r.raw, nil
+}
+
+// 设置第三方审核结果，会覆盖 AuditResponse 和 AuditResponseRaw 字段中已有的内容。
+//
+// AuditResponse 设置为解析后的 JSON 对象（便于读取），AuditResponseRaw 设置为原始响应内容，序列化时以原始响应内容原样透传。
+//
+// 目前支持华为 / OPPO / vivo 厂商。
+func (o *ThirdPartyChannelOptions) SetAuditResponse(r *AuditResponse) error {
+	if r == nil || len(r.raw) == 0 {
+		return errors.New("`auditResponse` cannot be empty")
+	}
+	o.AuditResponse, o.AuditResponseRaw = r.Map(), r.Raw()
+	return nil
+}
+
+// 序列化厂商通道选项，AuditResponseRaw 不为空时以其原样作为 auditResponse 字段。
+func (o ThirdPartyChannelOptions) MarshalJSON() ([]byte, error) {
+	type alias ThirdPartyChannelOptions
+	if len(o.AuditResponseRaw) == 0 {
+		return json.Marshal(alias(o))
+	}
+	return json.Marshal(struct {
+		alias
+		AuditResponse json.RawMessage `json:"auditResponse"`
+	}{alias(o), o.AuditResponseRaw})
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 私信模板填充参数
+//
+// 私信模板中形如 `${name}$` 的占位符与参数值的映射。
+type TemplateParams map[string]string
+
+// 校验模板填充参数：`template` 中的每个占位符都必须有非空的参数值，且不能有多余的参数。
+func (p TemplateParams) Validate(template string) error {
+	required := make(map[string]struct{})
+	for _, match := range templatePlaceholder.FindAllStringSubmatch(template, -1) {
+		required[match[1]] = struct{}{}
+	}
+	for name := range required {
+		if p[name] == "" {
+			return fmt.Errorf("missing template parameter %q", name)
+		}
+	}
+	for name := range p {
+		if _, ok := required[name]; !ok {
+			return fmt.Errorf("unknown template parameter %q", name)
+		}
+	}
+	return nil
+}
+
+// 转换为 PrivateTitleParameters / PrivateContentParameters 字段的 JSON 对象格式。
+func (p TemplateParams) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(p))
+	for k, v := range p {
+		m[k] = v
+	}
+	return m
+}
+
+// 按标题模板校验并设置标题模板填充参数，会覆盖 PrivateTitleParameters 字段中已有的内容。
+//
+// 仅支持 OPPO 厂商，需同时设置 PrivateMsgTemplateID。
+func (o *ThirdPartyChannelOptions) SetPrivateTitleParameters(template string, params TemplateParams) error {
+	if err := params.Validate(template); err != nil {
+		return fmt.Errorf("private title parameters: %w", err)
+	}
+	o.PrivateTitleParameters = params.Map()
+	return nil
+}
+
+// 按内容模板校验并设置内容模板填充参数，会覆盖 PrivateContentParameters 字段中已有的内容。
+//
+// 仅支持 OPPO 厂商，需同时设置 PrivateMsgTemplateID。
+func (o *ThirdPartyChannelOptions) SetPrivateContentParameters(template string, params TemplateParams) error {
+	if err := params.Validate(template); err != nil {
+		return fmt.Errorf("private content parameters: %w", err)
+	}
+	o.PrivateContentParameters = params.Map()
+	return nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultCacheTTL       = 5 * time.Minute // 默认的配额快照缓存时长
+	defaultThresholdRatio = 0.1             // 默认的剩余配额比例阈值
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 配额路由器配置。
+type config struct {
+	logger          jiguang.Logger    // 日志打印器，默认为 api.DefaultJPushLogger
+	cacheTTL        time.Duration     // 配额快照缓存时长，默认为 5 分钟
+	thresholdCount  int64             // 剩余配额数量阈值，低于该值时调整下发策略，默认为 0（不按数量判断）
+	thresholdRatio  float64           // 剩余配额比例阈值，低于该值时调整下发策略，默认为 0.1
+	fallback        Action            // 默认的调整动作，默认为 ActionSecondaryPush
+	vendorFallbacks map[Vendor]Action // 各厂商的调整动作
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 配额路由器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置配额路由器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 配额快照缓存时长配置选项。
+type cacheTTLOption time.Duration
+
+func (o cacheTTLOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`ttl` must be positive")
+	}
+	c.cacheTTL = time.Duration(o)
+	return nil
+}
+
+// 自定义配置配额快照的缓存时长，默认为 5 分钟。
+//
+// 注意：厂商配额查询 API 接口频率和 Push API 接口频率共享，缓存时长不宜过短。
+func WithCacheTTL(ttl time.Duration) ConfigOption {
+	return cacheTTLOption(ttl)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 剩余配额阈值配置选项。
+type thresholdOption struct {
+	count int64
+	ratio float64
+}
+
+func (o thresholdOption) apply(c *config) error {
+	if o.count < 0 {
+		return errors.New("`count` cannot be negative")
+	}
+	if o.ratio < 0 || o.ratio > 1 {
+		return errors.New("`ratio` must be between 0 and 1")
+	}
+	c.thresholdCount = o.count
+	c.thresholdRatio = o.ratio
+	return nil
+}
+
+// 自定义配置剩余配额阈值：当厂商的剩余配额数量低于 `count` 或剩余比例低于 `ratio` 时，调整该厂商的下发策略。
+//
+// 为 0 时表示不按该条件判断，默认为 count = 0、ratio = 0.1。
+func WithThreshold(count int64, ratio float64) ConfigOption {
+	return thresholdOption{count, ratio}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 调整动作配置选项。
+type fallbackOption struct {
+	vendor Vendor
+	action Action
+}
+
+func (o fallbackOption) apply(c *config) error {
+	if !o.action.valid() {
+		return errors.New("invalid `action`")
+	}
+	if o.vendor == "" {
+		c.fallback = o.action
+		return nil
+	}
+	if !o.vendor.valid() {
+		return errors.New("invalid `vendor`")
+	}
+	if o.action == ActionSkipQuota && o.vendor == VendorVivo {
+		return errors.New("`ActionSkipQuota` is not supported by vivo")
+	}
+	if c.vendorFallbacks == nil {
+		c.vendorFallbacks = make(map[Vendor]Action)
+	}
+	c.vendorFallbacks[o.vendor] = o.action
+	return nil
+}
+
+// 自定义配置剩余配额低于阈值时的默认调整动作，默认为 ActionSecondaryPush。
+func WithFallback(action Action) ConfigOption {
+	return fallbackOption{action: action}
+}
+
+// 自定义配置指定厂商的剩余配额低于阈值时的调整动作，优先级高于 WithFallback。
+func WithVendorFallback(vendor Vendor, action Action) ConfigOption {
+	return fallbackOption{vendor, action}
+}
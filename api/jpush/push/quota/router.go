@@ -0,0 +1,367 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device/platform"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// # 配额受限的厂商
+type Vendor string
+
+const (
+	VendorXiaomi Vendor = "xiaomi" // 小米
+	VendorOPPO   Vendor = "oppo"   // OPPO
+	VendorVivo   Vendor = "vivo"   // vivo
+)
+
+func (v Vendor) valid() bool {
+	return v == VendorXiaomi || v == VendorOPPO || v == VendorVivo
+}
+
+// # 下发策略调整动作
+type Action string
+
+const (
+	ActionNone          Action = "none"           // 不调整
+	ActionSecondaryPush Action = "secondary_push" // 将 Distribution 调整为 secondary_push：优先走极光，极光不在线再走厂商
+	ActionJPush         Action = "jpush"          // 将 Distribution 调整为 jpush：强制走极光通道，不消耗厂商配额
+	ActionSkipQuota     Action = "skip_quota"     // 设置 SkipQuota = true：跳过极光侧的配额判断及扣除，仅对小米和 OPPO 有效
+)
+
+func (a Action) valid() bool {
+	return a == ActionNone || a == ActionSecondaryPush || a == ActionJPush || a == ActionSkipQuota
+}
+
+// # 路由决策
+type Decision struct {
+	Vendor    Vendor // 厂商
+	System    bool   // 是否按系统消息配额判断，否则按运营消息配额判断
+	Total     int64  // 总配额
+	Used      int64  // 已使用配额
+	Remaining int64  // 剩余配额
+	Action    Action // 调整动作，剩余配额充足时为 ActionNone
+}
+
+func (d Decision) String() string {
+	bucket := "运营消息"
+	if d.System {
+		bucket = "系统消息"
+	}
+	return fmt.Sprintf("%s %s配额 %d/%d（已用 %d），动作：%s", d.Vendor, bucket, d.Remaining, d.Total, d.Used, d.Action)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 配额路由器
+//
+// 推送前的可选钩子：缓存 GetQuota 查询到的小米、OPPO、vivo 每日配额快照，当某厂商的剩余配额低于阈值时，
+// 在推送参数的副本中调整该厂商的 ThirdPartyChannel 下发策略（如改为 secondary_push、jpush 或设置 SkipQuota），并记录路由决策日志，
+// 避免营销类推送耗尽厂商配额后，重要的事务类推送因配额不足而失败（1012 错误）。
+//
+// 用法：在调用 Send 等推送接口前，对推送参数调用 Route 并推送返回的副本；或直接使用 Router.Send。
+// 原推送参数及其 Options 不会被修改，因此可以在多次推送或并发推送间复用。
+type Router struct {
+	push      push.APIv3
+	cfg       config
+	mu        sync.Mutex
+	snapshot  *push.QuotaData
+	fetchedAt time.Time
+	fetching  *fetch // 正在进行的配额查询，并发的查询只会请求一次
+	now       func() time.Time
+}
+
+// 正在进行的配额查询。
+type fetch struct {
+	done chan struct{}
+	data *push.QuotaData
+	err  error
+}
+
+// 创建新的配额路由器实例。
+func NewRouter(pushAPI push.APIv3, opts ...ConfigOption) (*Router, error) {
+	if pushAPI == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+
+	c := config{
+		logger:         api.DefaultJPushLogger,
+		cacheTTL:       defaultCacheTTL,
+		thresholdRatio: defaultThresholdRatio,
+		fallback:       ActionSecondaryPush,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Router{push: pushAPI, cfg: c, now: time.Now}, nil
+}
+
+// 获取厂商配额快照，快照在缓存时长内有效，过期后重新查询；重新查询失败时，如有旧快照，则继续使用旧快照。
+//
+// 并发调用时只会查询一次，查询期间不持有锁，其他调用方等待查询完成后共享结果。
+func (r *Router) Quota(ctx context.Context) (*push.QuotaData, error) {
+	for {
+		r.mu.Lock()
+		if r.snapshot != nil && r.now().Sub(r.fetchedAt) < r.cfg.cacheTTL {
+			snapshot := r.snapshot
+			r.mu.Unlock()
+			return snapshot, nil
+		}
+		f := r.fetching
+		if f == nil {
+			f = &fetch{done: make(chan struct{})}
+			r.fetching = f
+			r.mu.Unlock()
+			return r.fetch(ctx, f)
+		}
+		r.mu.Unlock()
+
+		select {
+		case <-f.done:
+		case <-ctx.Done():
+			return r.fallback(ctx, ctx.Err())
+		}
+		if f.err == nil {
+			return f.data, nil
+		}
+		// 发起查询的调用方被取消时，由仍在等待的调用方重新查询
+		if (errors.Is(f.err, context.Canceled) || errors.Is(f.err, context.DeadlineExceeded)) && ctx.Err() == nil {
+			continue
+		}
+		return r.fallback(ctx, f.err)
+	}
+}
+
+// 查询配额并在成功后替换快照。
+func (r *Router) fetch(ctx context.Context, f *fetch) (*push.QuotaData, error) {
+	result, err := r.push.GetQuota(ctx)
+	if err == nil {
+		err = api.CheckResponse(result.Response, result.Error)
+	}
+	if err == nil {
+		if f.data = result.Data; f.data == nil {
+			err = errors.New("empty quota data")
+		}
+	}
+
+	r.mu.Lock()
+	if r.fetching == f { // 查询期间调用了 Invalidate 时不替换快照
+		if err == nil {
+			r.snapshot, r.fetchedAt = f.data, r.now()
+		}
+		r.fetching = nil
+	}
+	f.err = err
+	r.mu.Unlock()
+	close(f.done)
+	if err != nil {
+		return r.fallback(ctx, err)
+	}
+	return f.data, nil
+}
+
+// 查询配额失败时，如有旧快照，则记录警告日志并继续使用旧快照。
+func (r *Router) fallback(ctx context.Context, err error) (*push.QuotaData, error) {
+	r.mu.Lock()
+	snapshot, fetchedAt := r.snapshot, r.fetchedAt
+	r.mu.Unlock()
+	if snapshot != nil {
+		r.cfg.logger.Warnf(ctx, "查询厂商配额失败，继续使用 %s 的配额快照：%s", fetchedAt.Format(time.RFC3339), err)
+		return snapshot, nil
+	}
+	return nil, err
+}
+
+// 使缓存的配额快照失效，下次路由时将重新查询。
+func (r *Router) Invalidate() {
+	r.mu.Lock()
+	r.snapshot, r.fetching = nil, nil
+	r.mu.Unlock()
+}
+
+// 根据厂商配额调整推送参数的 ThirdPartyChannel 下发策略，返回调整后的推送参数副本及各厂商的路由决策。
+//
+// 按 Options.Classification 判断使用系统消息（1）还是运营消息（默认）配额；不包含 Android 平台的推送不做调整。
+// 查询配额失败且无可用快照时，不调整推送参数，仅记录警告日志，不会阻止推送。
+//
+// 副本会复制被调整的 Options、ThirdPartyChannel 及厂商选项，`param` 本身不会被修改。
+//
+// 注意：Options 设置了 Classification 时，极光会忽略 SkipQuota，此时 ActionSkipQuota 会改为 ActionSecondaryPush；
+// vivo 不支持 SkipQuota，同样改为 ActionSecondaryPush。
+func (r *Router) Route(ctx context.Context, param *push.SendParam) (*push.SendParam, []Decision, error) {
+	if param == nil {
+		return nil, nil, errors.New("`param` cannot be nil")
+	}
+	routed := *param
+	if !targetsAndroid(param.Platform) {
+		return &routed, nil, nil
+	}
+
+	quota, err := r.Quota(ctx)
+	if err != nil {
+		r.cfg.logger.Warnf(ctx, "查询厂商配额失败，不调整下发策略：%s", err)
+		return &routed, nil, nil
+	}
+
+	classified := param.Options != nil && param.Options.Classification != nil
+	system := classified && *param.Options.Classification == 1
+
+	vendors := []struct {
+		vendor Vendor
+		quota  *push.MessageQuota
+		target func(*options.ThirdPartyChannel) **options.ThirdPartyChannelOptions
+	}{
+		{VendorXiaomi, quota.Xiaomi, func(c *options.ThirdPartyChannel) **options.ThirdPartyChannelOptions { return &c.Xiaomi }},
+		{VendorOPPO, quota.OPPO, func(c *options.ThirdPartyChannel) **options.ThirdPartyChannelOptions { return &c.OPPO }},
+		{VendorVivo, quota.Vivo, func(c *options.ThirdPartyChannel) **options.ThirdPartyChannelOptions { return &c.Vivo }},
+	}
+
+	var channel *options.ThirdPartyChannel // 副本中的 ThirdPartyChannel，首次调整时复制
+	decisions := make([]Decision, 0, len(vendors))
+	for _, v := range vendors {
+		d, ok := r.decide(v.vendor, v.quota, system)
+		if !ok {
+			continue
+		}
+		if d.Action == ActionSkipQuota && (classified || v.vendor == VendorVivo) {
+			d.Action = ActionSecondaryPush
+		}
+		if d.Action != ActionNone {
+			if channel == nil {
+				routed.Options, channel = cloneOptions(param.Options)
+			}
+			target := v.target(channel)
+			vendorOpts := &options.ThirdPartyChannelOptions{}
+			if *target != nil {
+				*vendorOpts = **target
+			}
+			apply(vendorOpts, d.Action)
+			*target = vendorOpts
+			r.cfg.logger.Infof(ctx, "厂商配额不足，调整下发策略：%s", d)
+		} else {
+			r.cfg.logger.Debugf(ctx, "厂商配额充足：%s", d)
+		}
+		decisions = append(decisions, d)
+	}
+	return &routed, decisions, nil
+}
+
+// 先调用 Route 得到调整后的推送参数副本，再调用 Send 推送该副本。
+func (r *Router) Send(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+	routed, _, err := r.Route(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	return r.push.Send(ctx, routed)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 根据配额快照做出路由决策，无配额数据或开通了不限量时返回 false。
+func (r *Router) decide(vendor Vendor, quota *push.MessageQuota, system bool) (Decision, bool) {
+	if quota == nil {
+		return Decision{}, false
+	}
+	detail := quota.Operation
+	if system {
+		detail = quota.System
+	}
+	if detail == nil || detail.Total == nil || *detail.Total < 0 {
+		return Decision{}, false
+	}
+
+	d := Decision{Vendor: vendor, System: system, Total: *detail.Total, Action: ActionNone}
+	if detail.Used != nil {
+		d.Used = *detail.Used
+	}
+	d.Remaining = d.Total - d.Used
+
+	low := d.Remaining <= 0 ||
+		(r.cfg.thresholdCount > 0 && d.Remaining < r.cfg.thresholdCount) ||
+		(r.cfg.thresholdRatio > 0 && d.Total > 0 && float64(d.Remaining)/float64(d.Total) < r.cfg.thresholdRatio)
+	if low {
+		d.Action = r.cfg.fallback
+		if action, ok := r.cfg.vendorFallbacks[vendor]; ok {
+			d.Action = action
+		}
+	}
+	return d, true
+}
+
+// 复制推送可选项及其 ThirdPartyChannel（各厂商选项仍与原值共享，调整前需单独复制），`o` 为 nil 时返回新的空值。
+func cloneOptions(o *options.Options) (*options.Options, *options.ThirdPartyChannel) {
+	cloned := &options.Options{}
+	if o != nil {
+		*cloned = *o
+	}
+	channel := &options.ThirdPartyChannel{}
+	if cloned.ThirdPartyChannel != nil {
+		*channel = *cloned.ThirdPartyChannel
+	}
+	cloned.ThirdPartyChannel = channel
+	return cloned, channel
+}
+
+func apply(o *options.ThirdPartyChannelOptions, action Action) {
+	switch action {
+	case ActionSecondaryPush:
+		if o.Distribution != string(ActionJPush) { // 已强制走极光通道的保持不变
+			o.Distribution = string(ActionSecondaryPush)
+		}
+	case ActionJPush:
+		o.Distribution = string(ActionJPush)
+	case ActionSkipQuota:
+		o.SkipQuota = jiguang.Bool(true)
+	}
+}
+
+// 判断推送平台是否包含 Android，无法识别的类型视为包含。
+func targetsAndroid(p interface{}) bool {
+	switch v := p.(type) {
+	case platform.Platform:
+		return v == platform.All || v == platform.Android
+	case string:
+		return v == string(platform.All) || v == string(platform.Android)
+	case []platform.Platform:
+		for _, pf := range v {
+			if pf == platform.All || pf == platform.Android {
+				return true
+			}
+		}
+		return false
+	case []string:
+		for _, pf := range v {
+			if pf == string(platform.All) || pf == string(platform.Android) {
+				return true
+			}
+		}
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultRefreshInterval = 5 * time.Minute // 默认的测试设备列表刷新间隔
+	testDevicesPageSize    = 200             // 获取测试设备列表的每页记录条数
+	maxRegIDsPerPush       = 1000            // 一次推送最多的 Registration ID 数量
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 预发推送配置。
+type config struct {
+	logger          jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	refreshInterval time.Duration  // 测试设备列表刷新间隔，默认为 5 分钟
+	deviceName      string         // 测试设备名称（模糊匹配），默认为空，表示使用所有测试设备
+	testModel       bool           // 是否强制开启测试模式（TestModel），默认为 true
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 预发推送配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置预发推送的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 刷新间隔配置选项。
+type refreshIntervalOption time.Duration
+
+func (o refreshIntervalOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`interval` must be positive")
+	}
+	c.refreshInterval = time.Duration(o)
+	return nil
+}
+
+// 自定义配置测试设备列表的刷新间隔，默认为 5 分钟；也可随时调用 Profile.Refresh 立即刷新。
+func WithRefreshInterval(interval time.Duration) ConfigOption {
+	return refreshIntervalOption(interval)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 测试设备名称配置选项。
+type deviceNameOption string
+
+func (o deviceNameOption) apply(c *config) error {
+	c.deviceName = string(o)
+	return nil
+}
+
+// 只使用名称匹配 `name`（模糊匹配，同 ListTestDevices 的 deviceName 参数）的测试设备，可用于按团队划分测试设备池。
+func WithDeviceName(name string) ConfigOption {
+	return deviceNameOption(name)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 测试模式配置选项。
+type testModelOption bool
+
+func (o testModelOption) apply(c *config) error {
+	c.testModel = bool(o)
+	return nil
+}
+
+// 不强制开启测试模式（Options.TestModel）。
+//
+// 测试模式为增值付费服务，未开通时推送会失败，此时可使用该选项；推送目标仍会被改写为测试设备列表。
+func WithoutTestModel() ConfigOption {
+	return testModelOption(false)
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+)
+
+var (
+	ErrBroadcastBlocked   = errors.New("broadcast audience is blocked in staging")          // 广播推送被拦截
+	ErrTagAudienceBlocked = errors.New("tag audience is blocked in staging")                // 标签推送被拦截
+	ErrAudienceBlocked    = errors.New("audience cannot be rewritten in staging")           // 用户分群、A/B 测试、实时活动、文件等无法改写的推送目标被拦截
+	ErrRequestBlocked     = errors.New("request cannot be rewritten in staging")            // 无法改写推送目标的请求（如自定义推送、文件推送、别名批量单推）被拦截
+	ErrNoTestDevices      = errors.New("no test devices available for the staging profile") // 没有可用的测试设备
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 预发推送
+//
+// 用于在预发环境中使用生产环境的 AppKey 和 Master Secret 安全地推送，避免打扰真实用户。Profile 实现了 push.APIv3，可替代原推送 API 使用：
+//   - 推送目标：改写为测试设备列表（通过 ListTestDevices 分页获取全部测试设备，并按 WithRefreshInterval 定期刷新）；
+//     广播和标签（tag、tag_and、tag_not）推送直接拦截，用户分群、A/B 测试、实时活动和文件等推送目标同样拦截；
+//   - 推送可选项：强制 ApnsProduction=false（推送到 APNs 开发环境），并强制 TestModel=true（可通过 WithoutTestModel 关闭）；
+//   - 普通推送、推送校验、模板推送、定时推送及更新定时任务会被改写；按 Registration ID 批量单推只允许推送给测试设备；
+//   - 文件推送、别名批量单推及所有自定义推送（Custom*）无法可靠改写，直接返回 ErrRequestBlocked；
+//   - 其余接口（如查询、撤销、推送计划管理、图片与文件上传）直接透传。
+//
+// 注意：定时推送的推送目标在创建（或更新）定时任务时即被改写为当时的测试设备列表。
+type Profile struct {
+	push.APIv3
+	device device.APIv3
+	cfg    config
+
+	mu       sync.Mutex
+	regIDs   []string
+	loadedAt time.Time
+}
+
+// 创建新的预发推送。
+//   - pushAPI：【必填】推送 API v3 接口；
+//   - deviceAPI：【必填】设备 API v3 接口，用于获取测试设备列表；
+//   - opts：【可选】预发推送配置选项。
+func NewProfile(pushAPI push.APIv3, deviceAPI device.APIv3, opts ...ConfigOption) (*Profile, error) {
+	if pushAPI == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+	if deviceAPI == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+
+	c := config{
+		logger:          api.DefaultJPushLogger,
+		refreshInterval: defaultRefreshInterval,
+		testModel:       true,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+	return &Profile{APIv3: pushAPI, device: deviceAPI, cfg: c}, nil
+}
+
+// 获取测试设备的 Registration ID 列表，超过刷新间隔时重新加载。
+func (p *Profile) TestDevices(ctx context.Context) ([]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.regIDs != nil && time.Since(p.loadedAt) < p.cfg.refreshInterval {
+		return p.regIDs, nil
+	}
+	return p.refresh(ctx)
+}
+
+// 立即重新加载测试设备列表。
+func (p *Profile) Refresh(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	_, err := p.refresh(ctx)
+	return err
+}
+
+// 改写推送参数：校验推送目标，将其替换为测试设备列表，并强制推送可选项；返回改写后的副本，不修改 `param`。
+func (p *Profile) Rewrite(ctx context.Context, param *push.SendParam) (*push.SendParam, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	if err := checkAudience(param.Audience); err != nil {
+		return nil, err
+	}
+	aud, err := p.audience(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rewritten := *param
+	rewritten.Audience = aud
+	rewritten.Options = p.options(param.Options)
+	return &rewritten, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 改写后普通推送。
+func (p *Profile) Send(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+	rewritten, err := p.Rewrite(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	return p.APIv3.Send(ctx, rewritten)
+}
+
+// 改写后普通推送（SM2 加密）。
+func (p *Profile) SendWithSM2(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+	rewritten, err := p.Rewrite(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	return p.APIv3.SendWithSM2(ctx, rewritten)
+}
+
+// 改写后推送校验。
+func (p *Profile) ValidateSend(ctx context.Context, param *push.SendParam) (*push.SendResult, error) {
+	rewritten, err := p.Rewrite(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	return p.APIv3.ValidateSend(ctx, rewritten)
+}
+
+// 文件推送的推送目标无法改写，直接拦截。
+func (p *Profile) SendByFile(context.Context, *push.SendParam) (*push.SendResult, error) {
+	return nil, fmt.Errorf("%w: SendByFile", ErrRequestBlocked)
+}
+
+// 按 Registration ID 批量单推，所有推送目标都必须是测试设备，并强制推送可选项。
+func (p *Profile) BatchSendByRegistrationID(ctx context.Context, pushList map[string]push.BatchPushParam) (*push.BatchSendResult, error) {
+	regIDs, err := p.TestDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	allowed := make(map[string]struct{}, len(regIDs))
+	for _, regID := range regIDs {
+		allowed[regID] = struct{}{}
+	}
+
+	rewritten := make(map[string]push.BatchPushParam, len(pushList))
+	for cid, param := range pushList {
+		if _, ok := allowed[param.Target]; !ok {
+			return nil, fmt.Errorf("%w: %s is not a test device", ErrAudienceBlocked, param.Target)
+		}
+		param.Options = p.options(param.Options)
+		rewritten[cid] = param
+	}
+	return p.APIv3.BatchSendByRegistrationID(ctx, rewritten)
+}
+
+// 别名批量单推的推送目标无法改写，直接拦截。
+func (p *Profile) BatchSendByAlias(context.Context, map[string]push.BatchPushParam) (*push.BatchSendResult, error) {
+	return nil, fmt.Errorf("%w: BatchSendByAlias", ErrRequestBlocked)
+}
+
+// 改写每个模板参数后模板推送。
+func (p *Profile) TemplateSend(ctx context.Context, id string, params []push.TemplateParam) (*push.TemplateSendResult, error) {
+	rewritten, err := p.rewriteTemplateParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return p.APIv3.TemplateSend(ctx, id, rewritten)
+}
+
+// 自定义推送无法可靠改写，直接拦截。
+func (p *Profile) CustomSend(context.Context, interface{}) (*push.SendResult, error) {
+	return nil, fmt.Errorf("%w: CustomSend", ErrRequestBlocked)
+}
+
+// 自定义文件推送无法可靠改写，直接拦截。
+func (p *Profile) CustomSendByFile(context.Context, interface{}) (*push.SendResult, error) {
+	return nil, fmt.Errorf("%w: CustomSendByFile", ErrRequestBlocked)
+}
+
+// 自定义推送校验无法可靠改写，直接拦截。
+func (p *Profile) ValidateCustomSend(context.Context, interface{}) (*push.SendResult, error) {
+	return nil, fmt.Errorf("%w: ValidateCustomSend", ErrRequestBlocked)
+}
+
+// 改写任务推送参数后创建定时任务。
+func (p *Profile) ScheduleSend(ctx context.Context, param *schedule.SendParam) (*schedule.SendResult, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	rewritten := *param
+	if param.Push != nil {
+		var err error
+		if rewritten.Push, err = p.Rewrite(ctx, param.Push); err != nil {
+			return nil, err
+		}
+	}
+	return p.APIv3.ScheduleSend(ctx, &rewritten)
+}
+
+// 改写每个模板参数后创建模板定时任务。
+func (p *Profile) ScheduleTemplateSend(ctx context.Context, id string, params []schedule.TemplateParam, scheduleName string, trigger *schedule.Trigger) (*schedule.TemplateSendResult, error) {
+	rewritten, err := p.rewriteTemplateParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return p.APIv3.ScheduleTemplateSend(ctx, id, rewritten, scheduleName, trigger)
+}
+
+// 改写任务推送参数（如有）后更新定时任务。
+func (p *Profile) UpdateSchedule(ctx context.Context, scheduleID string, param *schedule.UpdateParam) (*schedule.UpdateResult, error) {
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+	rewritten := *param
+	if param.Push != nil {
+		var err error
+		if rewritten.Push, err = p.Rewrite(ctx, param.Push); err != nil {
+			return nil, err
+		}
+	}
+	return p.APIv3.UpdateSchedule(ctx, scheduleID, &rewritten)
+}
+
+// 自定义定时推送无法可靠改写，直接拦截。
+func (p *Profile) CustomScheduleSend(context.Context, interface{}) (*schedule.SendResult, error) {
+	return nil, fmt.Errorf("%w: CustomScheduleSend", ErrRequestBlocked)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 分页加载全部测试设备，调用方需持有 p.mu。
+func (p *Profile) refresh(ctx context.Context) ([]string, error) {
+	var (
+		regIDs = make([]string, 0)
+		seen   = make(map[string]struct{})
+	)
+	for page := 1; ; page++ {
+		result, err := p.device.ListTestDevices(ctx, page, testDevicesPageSize, p.cfg.deviceName, "")
+		if err != nil {
+			return nil, err
+		}
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, fmt.Errorf("list test devices page %d: %w", page, err)
+		}
+		for _, d := range result.Detail {
+			if _, ok := seen[d.RegistrationID]; ok || d.RegistrationID == "" {
+				continue
+			}
+			seen[d.RegistrationID] = struct{}{}
+			regIDs = append(regIDs, d.RegistrationID)
+		}
+		if len(result.Detail) < testDevicesPageSize || page*testDevicesPageSize >= result.Total {
+			break
+		}
+	}
+
+	p.regIDs, p.loadedAt = regIDs, time.Now()
+	p.cfg.logger.Infof(ctx, "已加载 %d 个测试设备", len(regIDs))
+	return regIDs, nil
+}
+
+// 由测试设备列表构成的推送目标。
+func (p *Profile) audience(ctx context.Context) (*audience.Audience, error) {
+	regIDs, err := p.TestDevices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(regIDs) == 0 {
+		return nil, ErrNoTestDevices
+	}
+	if len(regIDs) > maxRegIDsPerPush {
+		p.cfg.logger.Warnf(ctx, "测试设备共 %d 个，超过单次推送 %d 个的上限，只推送给前 %d 个",
+			len(regIDs), maxRegIDsPerPush, maxRegIDsPerPush)
+		regIDs = regIDs[:maxRegIDsPerPush]
+	}
+	return &audience.Audience{RegistrationIDs: append([]string(nil), regIDs...)}, nil
+}
+
+// 强制推送可选项，返回副本。
+func (p *Profile) options(o *options.Options) *options.Options {
+	rewritten := &options.Options{}
+	if o != nil {
+		*rewritten = *o
+	}
+	production := false
+	rewritten.ApnsProduction = &production
+	if p.cfg.testModel {
+		testModel := true
+		rewritten.TestModel = &testModel
+	}
+	return rewritten
+}
+
+func (p *Profile) rewriteTemplateParams(ctx context.Context, params []push.TemplateParam) ([]push.TemplateParam, error) {
+	rewritten := make([]push.TemplateParam, len(params))
+	for i, param := range params {
+		if err := checkAudience(param.Audience); err != nil {
+			return nil, err
+		}
+		aud, err := p.audience(ctx)
+		if err != nil {
+			return nil, err
+		}
+		param.Audience = aud
+		param.Options = p.options(param.Options)
+		rewritten[i] = param
+	}
+	return rewritten, nil
+}
+
+// 校验推送目标是否允许改写：广播、标签及无法识别的推送目标会被拦截。
+func checkAudience(aud interface{}) error {
+	if aud == nil {
+		return nil
+	}
+	data, err := json.Marshal(aud)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAudienceBlocked, err)
+	}
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err = json.Unmarshal(data, &s); err == nil && s == audience.All {
+			return ErrBroadcastBlocked
+		}
+		return fmt.Errorf("%w: %s", ErrAudienceBlocked, data)
+	}
+
+	var a audience.Audience
+	if err = json.Unmarshal(data, &a); err != nil {
+		return fmt.Errorf("%w: %v", ErrAudienceBlocked, err)
+	}
+	switch {
+	case len(a.Tags) > 0 || len(a.AndTags) > 0 || len(a.NotTags) > 0:
+		return ErrTagAudienceBlocked
+	case len(a.Segments) > 0:
+		return fmt.Errorf("%w: segment", ErrAudienceBlocked)
+	case len(a.AbTests) > 0:
+		return fmt.Errorf("%w: abtest", ErrAudienceBlocked)
+	case a.LiveActivityID != "":
+		return fmt.Errorf("%w: live_activity_id", ErrAudienceBlocked)
+	case a.File != nil:
+		return fmt.Errorf("%w: file", ErrAudienceBlocked)
+	}
+	return nil
+}
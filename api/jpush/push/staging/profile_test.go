@@ -0,0 +1,240 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package staging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/audience"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push/options"
+)
+
+// 按页返回测试设备的设备 API。
+type fakeDevice struct {
+	device.APIv3
+
+	regIDs []string // 全部测试设备
+	total  int      // 接口返回的总记录数
+	pages  []int    // 请求过的页码
+}
+
+func (f *fakeDevice) ListTestDevices(_ context.Context, page, pageSize int, _, _ string) (*device.TestDevicesListResult, error) {
+	f.pages = append(f.pages, page)
+	result := &device.TestDevicesListResult{Response: &api.Response{StatusCode: 200}, Total: f.total, Page: page, PageSize: pageSize}
+	for i := (page - 1) * pageSize; i < page*pageSize && i < len(f.regIDs); i++ {
+		result.Detail = append(result.Detail, device.TestDeviceDetail{RegistrationID: f.regIDs[i]})
+	}
+	return result, nil
+}
+
+// 记录批量单推请求的推送 API。
+type fakePush struct {
+	push.APIv3
+
+	batch map[string]push.BatchPushParam
+}
+
+func (f *fakePush) BatchSendByRegistrationID(_ context.Context, pushList map[string]push.BatchPushParam) (*push.BatchSendResult, error) {
+	f.batch = pushList
+	return &push.BatchSendResult{Response: &api.Response{StatusCode: 200}}, nil
+}
+
+func regIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("rid%d", i)
+	}
+	return ids
+}
+
+func newTestProfile(t *testing.T, p *fakePush, d *fakeDevice, opts ...ConfigOption) *Profile {
+	t.Helper()
+	profile, err := NewProfile(p, d, opts...)
+	if err != nil {
+		t.Fatalf("new profile: %v", err)
+	}
+	return profile
+}
+
+func TestCheckAudience(t *testing.T) {
+	tests := []struct {
+		name string
+		aud  interface{}
+		want error // nil 表示允许改写
+	}{
+		{name: "nil", aud: nil},
+		{name: "registration ids", aud: &audience.Audience{RegistrationIDs: []string{"rid"}}},
+		{name: "aliases", aud: audience.Audience{Aliases: []string{"u1"}}},
+		{name: "broadcast", aud: audience.All, want: ErrBroadcastBlocked},
+		{name: "tag map", aud: map[string]interface{}{"tag": []string{"vip"}}, want: ErrTagAudienceBlocked},
+		{name: "other string", aud: "everyone", want: ErrAudienceBlocked},
+		{name: "tag", aud: &audience.Audience{Tags: []string{"vip"}}, want: ErrTagAudienceBlocked},
+		{name: "tag_and", aud: &audience.Audience{AndTags: []string{"vip"}}, want: ErrTagAudienceBlocked},
+		{name: "tag_not", aud: &audience.Audience{NotTags: []string{"vip"}}, want: ErrTagAudienceBlocked},
+		{name: "segment", aud: &audience.Audience{Segments: []string{"s1"}}, want: ErrAudienceBlocked},
+		{name: "abtest", aud: &audience.Audience{AbTests: []string{"ab1"}}, want: ErrAudienceBlocked},
+		{name: "live_activity_id", aud: &audience.Audience{LiveActivityID: "la1"}, want: ErrAudienceBlocked},
+		{name: "file", aud: &audience.Audience{File: &audience.File{FileID: "f1"}}, want: ErrAudienceBlocked},
+		{name: "unmarshalable", aud: func() {}, want: ErrAudienceBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAudience(tt.aud)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("checkAudience() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("checkAudience() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchSendByRegistrationID(t *testing.T) {
+	tests := []struct {
+		name    string
+		targets []string
+		wantErr error
+	}{
+		{name: "test devices", targets: []string{"rid0", "rid1"}},
+		{name: "non-test device", targets: []string{"rid0", "real"}, wantErr: ErrAudienceBlocked},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, d := &fakePush{}, &fakeDevice{regIDs: regIDs(3), total: 3}
+			profile := newTestProfile(t, p, d)
+
+			pushList := make(map[string]push.BatchPushParam)
+			for i, target := range tt.targets {
+				pushList[fmt.Sprintf("cid%d", i)] = push.BatchPushParam{Target: target}
+			}
+			_, err := profile.BatchSendByRegistrationID(context.Background(), pushList)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tt.wantErr)
+				}
+				if p.batch != nil {
+					t.Error("blocked request was sent")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("batch send: %v", err)
+			}
+			if len(p.batch) != len(tt.targets) {
+				t.Fatalf("sent %d params, want %d", len(p.batch), len(tt.targets))
+			}
+			for cid, param := range p.batch {
+				if o := param.Options; o == nil || o.ApnsProduction == nil || *o.ApnsProduction || o.TestModel == nil || !*o.TestModel {
+					t.Errorf("%s: options not forced: %+v", cid, o)
+				}
+			}
+		})
+	}
+}
+
+func TestOptions(t *testing.T) {
+	yes, ttl := true, int64(60)
+	tests := []struct {
+		name          string
+		opts          []ConfigOption
+		in            *options.Options
+		wantTestModel *bool
+	}{
+		{name: "nil options", in: nil, wantTestModel: &yes},
+		{name: "production options", in: &options.Options{ApnsProduction: &yes, TimeToLive: &ttl}, wantTestModel: &yes},
+		{name: "without test model", opts: []ConfigOption{WithoutTestModel()}, in: &options.Options{}, wantTestModel: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := newTestProfile(t, &fakePush{}, &fakeDevice{}, tt.opts...)
+			var before options.Options
+			if tt.in != nil {
+				before = *tt.in
+			}
+
+			got := profile.options(tt.in)
+			if got == tt.in {
+				t.Fatal("options() returned the input instead of a copy")
+			}
+			if got.ApnsProduction == nil || *got.ApnsProduction {
+				t.Errorf("ApnsProduction = %v, want false", got.ApnsProduction)
+			}
+			if !reflect.DeepEqual(got.TestModel, tt.wantTestModel) {
+				t.Errorf("TestModel = %v, want %v", got.TestModel, tt.wantTestModel)
+			}
+			if tt.in != nil {
+				if !reflect.DeepEqual(*tt.in, before) {
+					t.Errorf("input mutated: %+v, want %+v", *tt.in, before)
+				}
+				if got.TimeToLive != tt.in.TimeToLive {
+					t.Errorf("TimeToLive = %v, want %v", got.TimeToLive, tt.in.TimeToLive)
+				}
+			}
+		})
+	}
+}
+
+func TestRefreshPagination(t *testing.T) {
+	tests := []struct {
+		name      string
+		devices   int
+		total     int
+		wantPages []int
+		wantCount int
+	}{
+		{name: "empty", devices: 0, total: 0, wantPages: []int{1}, wantCount: 0},
+		{name: "single short page", devices: 50, total: 50, wantPages: []int{1}, wantCount: 50},
+		{name: "exactly one page", devices: 200, total: 200, wantPages: []int{1}, wantCount: 200},
+		{name: "multiple pages", devices: 450, total: 450, wantPages: []int{1, 2, 3}, wantCount: 450},
+		{name: "stop at total", devices: 600, total: 400, wantPages: []int{1, 2}, wantCount: 400},
+		{name: "stop at short page", devices: 250, total: 1000, wantPages: []int{1, 2}, wantCount: 250},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &fakeDevice{regIDs: regIDs(tt.devices), total: tt.total}
+			profile := newTestProfile(t, &fakePush{}, d)
+
+			got, err := profile.TestDevices(context.Background())
+			if err != nil {
+				t.Fatalf("test devices: %v", err)
+			}
+			if !reflect.DeepEqual(d.pages, tt.wantPages) {
+				t.Errorf("pages = %v, want %v", d.pages, tt.wantPages)
+			}
+			if len(got) != tt.wantCount {
+				t.Errorf("loaded %d devices, want %d", len(got), tt.wantCount)
+			}
+
+			// 刷新间隔内不再重新加载
+			if _, err = profile.TestDevices(context.Background()); err != nil {
+				t.Fatalf("test devices: %v", err)
+			}
+			if len(d.pages) != len(tt.wantPages) {
+				t.Errorf("reloaded within refresh interval: pages = %v", d.pages)
+			}
+		})
+	}
+}
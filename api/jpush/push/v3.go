@@ -51,6 +51,8 @@ type APIv3 interface {
 	//  - 功能说明：向某单个设备或者某设备列表推送一条通知或者消息。推送的内容只能是 JSON 表示的一个推送对象。
 	//	- 调用地址：POST `/v3/push`
 	//  - 接口文档：[docs.jiguang.cn]
+	// 如需对所有推送类接口的请求正文进行 SM2 加密，可在构建客户端时调用 APIv3Builder.EnableSM2Encryption。
+	// 如需使用自定义或轮换中的 SM2 公钥，可在构建客户端时调用 APIv3Builder.SetSM2KeyProvider。
 	// [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_v3_push
 	SendWithSM2(ctx context.Context, param *SendParam) (*SendResult, error)
 
@@ -69,6 +69,11 @@ func (p *apiv3) batchSend(ctx context.Context, byType string, pushList map[strin
 		Auth:   p.auth,
 		Body:   &batchSendParam{PushList: pushList},
 	}
+	if p.sm2 {
+		if err := req.EncryptWithSM2(p.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
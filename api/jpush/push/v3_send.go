@@ -21,7 +21,6 @@ import (
 	"net/http"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
-	"github.com/cavlabs/jiguang-sdk-go/jiguang"
 )
 
 // # 普通推送
@@ -53,6 +52,11 @@ func (p *apiv3) CustomSend(ctx context.Context, param interface{}) (*SendResult,
 		Auth:   p.auth,
 		Body:   param,
 	}
+	if p.sm2 {
+		if err := req.EncryptWithSM2(p.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
@@ -81,23 +85,15 @@ func (p *apiv3) SendWithSM2(ctx context.Context, param *SendParam) (*SendResult,
 		return nil, errors.New("`param` cannot be nil")
 	}
 
-	original, err := json.Marshal(param)
-	if err != nil {
-		return nil, err
-	}
-	payload, err := jiguang.EncryptWithSM2(original)
-	if err != nil {
-		return nil, err
-	}
-	sm2PushParam := &sm2Push{Audience: param.Audience, Payload: payload}
-
 	req := &api.Request{
 		Method: http.MethodPost,
 		Proto:  p.proto,
 		URL:    p.host + "/v3/push",
 		Auth:   p.auth,
-		Header: http.Header{"X-Encrypt-Type": {"SM2"}},
-		Body:   sm2PushParam,
+		Body:   param,
+	}
+	if err := req.EncryptWithSM2(p.sm2Keys); err != nil {
+		return nil, err
 	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
@@ -112,12 +108,6 @@ func (p *apiv3) SendWithSM2(ctx context.Context, param *SendParam) (*SendResult,
 	return result, nil
 }
 
-// SM2 加密推送参数
-type sm2Push struct {
-	Audience interface{} `json:"audience"` // 推送目标，同 SendParam.Audience
-	Payload  string      `json:"payload"`  // 推送内容，SendParam 的 JSON 字符串使用 SM2 公钥加密后的密文（Base64 编码）
-}
-
 type SendResult struct {
 	*api.Response `json:"-"`
 	Error         *api.CodeError `json:"error,omitempty"`
@@ -57,6 +57,11 @@ func (p *apiv3) CustomSendByFile(ctx context.Context, param interface{}) (*SendR
 		Auth:   p.auth,
 		Body:   param,
 	}
+	if p.sm2 {
+		if err := req.EncryptWithSM2(p.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
@@ -60,6 +60,11 @@ func (p *apiv3) TemplateSend(ctx context.Context, id string, params []TemplatePa
 		Auth:   p.auth,
 		Body:   &templateSendParam{ID: id, Params: params},
 	}
+	if p.sm2 {
+		if err := req.EncryptWithSM2(p.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := p.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
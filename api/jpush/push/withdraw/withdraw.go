@@ -0,0 +1,264 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package withdraw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/push"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+var (
+	ErrNoMsgIDs   = errors.New("no msg_id to withdraw")                // 没有需要撤销的消息
+	ErrIncomplete = errors.New("not all messages have been withdrawn") // 部分消息撤销失败
+)
+
+// # 定时任务处理方式
+type ScheduleAction int
+
+const (
+	KeepSchedule    ScheduleAction = iota // 保留定时任务，仅撤销已推送的消息
+	DisableSchedule                       // 撤销前先禁用定时任务，避免其继续触发推送
+	DeleteSchedule                        // 撤销前先禁用定时任务，全部消息撤销成功后再删除定时任务
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 单条消息的撤销结果
+type Outcome struct {
+	MsgID  string                      // 推送消息 ID
+	Result *push.WithdrawMessageResult // 撤销接口的响应结果，请求失败时为 nil
+	Err    error                       // 撤销失败的原因，撤销成功时为 nil
+}
+
+func (o *Outcome) IsSuccess() bool {
+	return o != nil && o.Err == nil
+}
+
+// # 批量撤销结果
+type Result struct {
+	ScheduleID  string    // 定时任务 ID，仅 WithdrawSchedule 返回
+	Outcomes    []Outcome // 每条消息的撤销结果，顺序与输入（或定时任务的消息 ID 列表）一致
+	Disabled    bool      // 定时任务是否已被禁用
+	Deleted     bool      // 定时任务是否已被删除
+	ScheduleErr error     // 禁用或删除定时任务失败的原因
+}
+
+// 撤销成功的消息 ID 列表。
+func (rs *Result) Succeeded() []string {
+	return rs.filter(true)
+}
+
+// 撤销失败的消息 ID 列表，可用于重试。
+func (rs *Result) Failed() []string {
+	return rs.filter(false)
+}
+
+// 是否所有消息均撤销成功，且定时任务（如有）已按要求处理。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && rs.ScheduleErr == nil && len(rs.Failed()) == 0
+}
+
+func (rs *Result) filter(success bool) []string {
+	if rs == nil {
+		return nil
+	}
+	msgIDs := make([]string, 0, len(rs.Outcomes))
+	for i := range rs.Outcomes {
+		if rs.Outcomes[i].IsSuccess() == success {
+			msgIDs = append(msgIDs, rs.Outcomes[i].MsgID)
+		}
+	}
+	return msgIDs
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 批量撤销器
+//
+// WithdrawMessage 每次只能撤销一条消息，当一次定时推送或分批推送出现问题时，可使用批量撤销器尽快撤回所有已推送的消息：
+//   - WithdrawMessages：并发撤销指定的消息 ID 列表；
+//   - WithdrawSchedule：通过 GetScheduleMsgIDs 获取定时任务已推送的所有消息 ID 并并发撤销，可选地禁用及删除该定时任务。
+//
+// 撤销请求受 WithConcurrency 和 WithRateLimiter 的限制，并会根据响应头中的频率控制信息自动暂停。
+type Withdrawer struct {
+	push push.APIv3
+	cfg  config
+}
+
+// 创建新的批量撤销器实例。
+func NewWithdrawer(pushAPI push.APIv3, opts ...ConfigOption) (*Withdrawer, error) {
+	if pushAPI == nil {
+		return nil, api.ErrNilJPushPushAPIv3
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		concurrency: defaultConcurrency,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Withdrawer{push: pushAPI, cfg: c}, nil
+}
+
+// 并发撤销指定的消息 ID 列表（重复及空的消息 ID 会被忽略）。
+//
+// 单条消息撤销失败不会中断其他消息的撤销，具体结果详见 Result.Outcomes。
+func (w *Withdrawer) WithdrawMessages(ctx context.Context, msgIDs []string) (*Result, error) {
+	msgIDs = api.DedupeStrings(msgIDs)
+	if len(msgIDs) == 0 {
+		return nil, ErrNoMsgIDs
+	}
+	return &Result{Outcomes: w.withdraw(ctx, msgIDs)}, nil
+}
+
+// 撤销指定定时任务已推送的所有消息。
+//
+// `action` 为 DisableSchedule 或 DeleteSchedule 时，会在获取消息 ID 列表前先禁用该定时任务，避免撤销期间继续触发推送；
+// 为 DeleteSchedule 时，仅在所有消息均撤销成功后才删除该定时任务，以便撤销失败时仍可通过定时任务找回消息 ID 进行重试。
+// 禁用或删除定时任务失败不会中断撤销，失败原因详见 Result.ScheduleErr。
+func (w *Withdrawer) WithdrawSchedule(ctx context.Context, scheduleID string, action ScheduleAction) (*Result, error) {
+	if scheduleID == "" {
+		return nil, errors.New("`scheduleID` cannot be empty")
+	}
+
+	result := &Result{ScheduleID: scheduleID}
+
+	if action == DisableSchedule || action == DeleteSchedule {
+		if err := w.disable(ctx, scheduleID); err != nil {
+			w.cfg.logger.Warnf(ctx, "禁用定时任务 %s 失败：%s", scheduleID, err)
+			result.ScheduleErr = fmt.Errorf("disable schedule: %w", err)
+		} else {
+			result.Disabled = true
+		}
+	}
+
+	msgIDs, err := w.resolve(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if len(msgIDs) == 0 {
+		w.cfg.logger.Infof(ctx, "定时任务 %s 暂无已推送的消息", scheduleID)
+	} else {
+		result.Outcomes = w.withdraw(ctx, msgIDs)
+	}
+
+	if action == DeleteSchedule && result.ScheduleErr == nil {
+		if failed := len(result.Failed()); failed > 0 {
+			w.cfg.logger.Warnf(ctx, "定时任务 %s 有 %d 条消息撤销失败，暂不删除该定时任务", scheduleID, failed)
+			result.ScheduleErr = ErrIncomplete
+		} else if err = w.delete(ctx, scheduleID); err != nil {
+			w.cfg.logger.Warnf(ctx, "删除定时任务 %s 失败：%s", scheduleID, err)
+			result.ScheduleErr = fmt.Errorf("delete schedule: %w", err)
+		} else {
+			result.Deleted = true
+		}
+	}
+
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 获取定时任务已推送的所有消息 ID，执行失败（未实际推送）的记录会被忽略。
+func (w *Withdrawer) resolve(ctx context.Context, scheduleID string) ([]string, error) {
+	result, err := w.push.GetScheduleMsgIDs(ctx, scheduleID)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+
+	msgIDs := make([]string, 0, len(result.MsgIDs))
+	for _, s := range result.MsgIDs {
+		// 2018-09-13 后的新数据格式
+		if m, ok := schedule.TryParseScheduleMsgIDFromString(s); ok {
+			if m.MsgID == "" || !m.IsSuccess() {
+				w.cfg.logger.Debugf(ctx, "忽略定时任务 %s 执行失败的记录：%s", scheduleID, s)
+				continue
+			}
+			s = m.MsgID
+		}
+		msgIDs = append(msgIDs, s)
+	}
+	return api.DedupeStrings(msgIDs), nil
+}
+
+func (w *Withdrawer) disable(ctx context.Context, scheduleID string) error {
+	result, err := w.push.UpdateSchedule(ctx, scheduleID, &schedule.UpdateParam{Enabled: jiguang.Bool(false)})
+	if err != nil {
+		return err
+	}
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+func (w *Withdrawer) delete(ctx context.Context, scheduleID string) error {
+	result, err := w.push.DeleteSchedule(ctx, scheduleID)
+	if err != nil {
+		return err
+	}
+	return api.CheckResponse(result.Response, result.Error)
+}
+
+// 并发撤销消息，返回的结果顺序与 `msgIDs` 一致。
+func (w *Withdrawer) withdraw(ctx context.Context, msgIDs []string) []Outcome {
+	outcomes := make([]Outcome, len(msgIDs))
+	api.RunConcurrently(len(msgIDs), w.cfg.concurrency, func(i int) {
+		outcomes[i] = w.withdrawOne(ctx, msgIDs[i])
+	})
+
+	failed := 0
+	for i := range outcomes {
+		if !outcomes[i].IsSuccess() {
+			failed++
+		}
+	}
+	w.cfg.logger.Infof(ctx, "批量撤销完成：成功 %d 条，失败 %d 条", len(outcomes)-failed, failed)
+	return outcomes
+}
+
+func (w *Withdrawer) withdrawOne(ctx context.Context, msgID string) Outcome {
+	outcome := Outcome{MsgID: msgID}
+	if outcome.Err = w.cfg.limiter.Wait(ctx); outcome.Err != nil {
+		return outcome
+	}
+
+	outcome.Result, outcome.Err = w.push.WithdrawMessage(ctx, msgID)
+	if outcome.Err == nil {
+		w.cfg.limiter.Observe(outcome.Result.Rate)
+		outcome.Err = api.CheckResponse(outcome.Result.Response, outcome.Result.Error)
+	}
+	if outcome.Err != nil {
+		w.cfg.logger.Warnf(ctx, "撤销消息 %s 失败：%s", msgID, outcome.Err)
+	}
+	return outcome
+}
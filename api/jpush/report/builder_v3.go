@@ -17,6 +17,7 @@ package report
 import (
 	"encoding/base64"
 	"errors"
+	"time"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
@@ -30,6 +31,7 @@ type APIv3Builder struct {
 	masterSecret string
 	logger       jiguang.Logger
 	httpLogLevel api.HttpLogLevel
+	loc          *time.Location
 	err          error
 }
 
@@ -95,6 +97,18 @@ func (b *APIv3Builder) DisableHttpLogging() *APIv3Builder {
 	return b
 }
 
+// 【可选】设置 API 使用的时区，默认为 jiguang.APILocation()（Asia/Shanghai）。
+//
+// GetUserDetail 在查询前，会将小时粒度的起始时间换算到该时区；天、月粒度的起始时间按其墙上日期查询，不做换算。
+func (b *APIv3Builder) SetAPILocation(loc *time.Location) *APIv3Builder {
+	if loc == nil {
+		b.err = errors.New("`loc` cannot be nil")
+		return b
+	}
+	b.loc = loc
+	return b
+}
+
 func (b *APIv3Builder) Build() (APIv3, error) {
 	if b.err != nil {
 		return (*apiv3)(nil), b.err
@@ -112,6 +126,7 @@ func (b *APIv3Builder) Build() (APIv3, error) {
 		proto:  proto,
 		host:   b.host,
 		auth:   "Basic " + creds,
+		loc:    b.loc,
 	}, nil
 }
 
@@ -121,4 +136,5 @@ type apiv3 struct {
 	proto  string
 	host   string
 	auth   string
+	loc    *time.Location // API 使用的时区，为 nil 时使用 jiguang.APILocation()
 }
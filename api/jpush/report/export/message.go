@@ -0,0 +1,255 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/greport"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report/funnel"
+)
+
+// 数据来源，与 funnel 包的定义一致。
+const (
+	SourceDetail   = funnel.SourceDetail   // 消息统计详情，2021.09.01 新体系指标
+	SourceLegacy   = funnel.SourceLegacy   // 消息统计详情，2021.09.01 前旧体系指标
+	SourceReceived = funnel.SourceReceived // 送达统计详情
+	SourceGroup    = funnel.SourceGroup    // 分组消息统计详情
+)
+
+// 汇总行的平台与发送通道取值，与细分行同时存在，按通道求和时需排除。
+const ChannelAll = "all"
+
+// Windows Phone 平台及其 MPNS 通道，仅出现在送达统计详情中。
+const (
+	PlatformWinPhone = "winphone"
+	ChannelMPNS      = "mpns"
+)
+
+// 消息统计导出的列名，依次为：
+//   - msg_id：推送消息 ID，分组消息统计为分组推送消息 ID；
+//   - source：数据来源，如 SourceDetail、SourceLegacy、SourceReceived、SourceGroup；
+//   - type：消息类型，如 funnel.TypeNotification、funnel.TypeMessage，数据未区分消息类型时为空；
+//   - platform：平台，如 funnel.PlatformAndroid，不区分平台时为 "all"；
+//   - channel：发送通道，如 funnel.ChannelXiaomi；"pns" 表示未细分厂商的厂商通道，"all" 表示该消息类型（及平台）的汇总；
+//   - target、sent、received、display、click：有效目标、发送、送达、展示、点击数量，未返回的指标为空（JSONL 中为 null）。
+var MessageColumns = []string{"msg_id", "source", "type", "platform", "channel", "target", "sent", "received", "display", "click"}
+
+// # 消息统计导出行
+//
+// 每行对应一条消息在某个消息类型、平台和发送通道下的指标。
+type MessageRow struct {
+	MsgID    string  `json:"msg_id"`
+	Source   string  `json:"source"`
+	Type     string  `json:"type"`
+	Platform string  `json:"platform"`
+	Channel  string  `json:"channel"`
+	Target   *uint64 `json:"target"`
+	Sent     *uint64 `json:"sent"`
+	Received *uint64 `json:"received"`
+	Display  *uint64 `json:"display"`
+	Click    *uint64 `json:"click"`
+}
+
+// 按 MessageColumns 的顺序返回各列的值。
+func (r MessageRow) Record() []string {
+	return []string{r.MsgID, r.Source, r.Type, r.Platform, r.Channel,
+		format(r.Target), format(r.Sent), format(r.Received), format(r.Display), format(r.Click)}
+}
+
+// # 消息统计导出行列表
+type MessageRows []MessageRow
+
+// 以 CSV 格式（含表头，列见 MessageColumns）写入 `w`。
+func (rows MessageRows) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(MessageColumns); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.Record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// 以 JSON Lines 格式（每行一个 JSON 对象，字段见 MessageColumns）写入 `w`。
+func (rows MessageRows) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 展开「消息统计详情」，同时包含新体系指标（source 为 SourceDetail）与旧体系指标（source 为 SourceLegacy）。
+//
+// 新体系指标中，每种消息类型会输出一行 platform、channel 均为 "all" 的汇总行；旧体系指标按 funnel.WalkLegacy 的口径划分通道。
+//
+// 注意：同一条消息可能同时返回新旧两套指标，按消息汇总时需按 source 列只取其中一套，否则会重复计数；
+// 也可以使用 FromMessageDetailsNormalized 只导出其中一套。
+func FromMessageDetails(details []report.MessageDetail) MessageRows {
+	var rows MessageRows
+	for i := range details {
+		rows = append(rows, detailRows(&details[i])...)
+		rows = append(rows, legacyRows(&details[i])...)
+	}
+	return rows
+}
+
+// 按 funnel.Normalize 的规则展开「消息统计详情」：有数据的新体系指标优先（source 为 SourceDetail），否则使用旧体系指标（source 为 SourceLegacy），
+// 每条消息只输出其中一套指标。
+func FromMessageDetailsNormalized(details []report.MessageDetail) MessageRows {
+	var rows MessageRows
+	for i := range details {
+		d := &details[i]
+		if funnel.Normalize(d).Source == SourceDetail {
+			rows = append(rows, detailRows(d)...)
+		} else {
+			rows = append(rows, legacyRows(d)...)
+		}
+	}
+	return rows
+}
+
+// 展开「分组消息统计详情」（source 为 SourceGroup），msg_id 列为分组推送消息 ID，按 funnel.WalkGroupMessageDetail 的口径划分通道。
+func FromGroupMessageDetails(details []greport.MessageDetail) MessageRows {
+	var rows MessageRows
+	for i := range details {
+		d := &details[i]
+		b := builder{msgID: d.GroupMsgID, source: SourceGroup}
+		funnel.WalkGroupMessageDetail(d, b.add)
+		rows = append(rows, b.rows...)
+	}
+	return rows
+}
+
+// 展开「送达统计详情」（source 为 SourceReceived），只包含发送和送达指标。
+func FromReceivedDetails(details []report.ReceivedDetail) MessageRows {
+	var rows MessageRows
+	for i := range details {
+		d := &details[i]
+		b := builder{msgID: d.MsgID, source: SourceReceived}
+		b.add("", funnel.PlatformAll, funnel.ChannelJiguang, nil, nil, d.JPushReceived, nil, nil)
+		b.add("", funnel.PlatformAndroid, funnel.ChannelPns, nil, d.AndroidPnsSent, d.AndroidPnsReceived, nil, nil)
+		b.add(funnel.TypeNotification, funnel.PlatformIOS, funnel.ChannelAPNs, nil, d.IOSApnsSent, d.IOSApnsReceived, nil, nil)
+		b.add(funnel.TypeMessage, funnel.PlatformIOS, funnel.ChannelJiguang, nil, nil, d.IOSMsgReceived, nil, nil)
+		b.add(funnel.TypeLiveActivity, funnel.PlatformIOS, funnel.ChannelAPNs, nil, d.LiveActivitySent, d.LiveActivityReceived, nil, nil)
+		b.add("", PlatformWinPhone, ChannelMPNS, nil, d.WpMpnsSent, nil, nil, nil)
+		b.add("", funnel.PlatformQuickApp, funnel.ChannelPns, nil, d.QuickAppPnsSent, nil, nil, nil)
+		b.add("", funnel.PlatformQuickApp, funnel.ChannelJiguang, nil, nil, d.QuickAppJPushReceived, nil, nil)
+		b.add(funnel.TypeNotification, funnel.PlatformHMOS, funnel.ChannelHmpns, nil, d.HmosHmpnsSent, d.HmosHmpnsReceived, nil, nil)
+		b.add(funnel.TypeMessage, funnel.PlatformHMOS, funnel.ChannelHmpns, nil, d.HmosMsgSent, d.HmosMsgReceived, nil, nil)
+		rows = append(rows, b.rows...)
+	}
+	return rows
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func detailRows(d *report.MessageDetail) MessageRows {
+	b := builder{msgID: d.MsgID, source: SourceDetail}
+	if d.Details != nil {
+		b.messageStats(funnel.TypeNotification, d.Details.Notification)
+		b.messageStats(funnel.TypeMessage, d.Details.CustomMessage)
+		b.messageStats(funnel.TypeInApp, d.Details.InApp)
+		if la := d.Details.LiveActivity; la != nil {
+			b.add(funnel.TypeLiveActivity, funnel.PlatformAll, ChannelAll, la.Target, la.Sent, la.Received, la.Display, la.Click)
+			b.channel(funnel.TypeLiveActivity, funnel.PlatformIOS, funnel.ChannelAPNs, la.SubIos)
+		}
+	}
+	return b.rows
+}
+
+func legacyRows(d *report.MessageDetail) MessageRows {
+	b := builder{msgID: d.MsgID, source: SourceLegacy}
+	funnel.WalkLegacy(d, b.add)
+	return b.rows
+}
+
+type builder struct {
+	msgID  string
+	source string
+	rows   MessageRows
+}
+
+// 添加一行，所有指标均未返回时忽略。
+func (b *builder) add(typ, platform, channel string, target, sent, received, display, click *uint64) {
+	if target == nil && sent == nil && received == nil && display == nil && click == nil {
+		return
+	}
+	b.rows = append(b.rows, MessageRow{
+		MsgID: b.msgID, Source: b.source, Type: typ, Platform: platform, Channel: channel,
+		Target: target, Sent: sent, Received: received, Display: display, Click: click,
+	})
+}
+
+func (b *builder) channel(typ, platform, channel string, s *report.ChannelStats) {
+	if s != nil {
+		b.add(typ, platform, channel, s.Target, s.Sent, s.Received, s.Display, s.Click)
+	}
+}
+
+func (b *builder) messageStats(typ string, s *report.MessageStats) {
+	if s == nil {
+		return
+	}
+	b.add(typ, funnel.PlatformAll, ChannelAll, s.Target, s.Sent, s.Received, s.Display, s.Click)
+	if a := s.SubAndroid; a != nil {
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelJiguang, a.Jiguang)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelXiaomi, a.Xiaomi)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelHuawei, a.Huawei)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelHonor, a.Honor)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelMeizu, a.Meizu)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelOPPO, a.OPPO)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelVivo, a.Vivo)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelASUS, a.ASUS)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelFCM, a.FCM)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelTuibida, a.Tuibida)
+		b.channel(typ, funnel.PlatformAndroid, funnel.ChannelNIO, a.NIO)
+	}
+	if i := s.SubIos; i != nil {
+		b.channel(typ, funnel.PlatformIOS, funnel.ChannelJiguang, i.Jiguang)
+		b.channel(typ, funnel.PlatformIOS, funnel.ChannelVoIP, i.VoIP)
+		b.channel(typ, funnel.PlatformIOS, funnel.ChannelAPNs, i.APNs)
+	}
+	if q := s.SubQuickApp; q != nil {
+		b.channel(typ, funnel.PlatformQuickApp, funnel.ChannelJiguang, q.Jiguang)
+		b.channel(typ, funnel.PlatformQuickApp, funnel.ChannelXiaomi, q.Xiaomi)
+		b.channel(typ, funnel.PlatformQuickApp, funnel.ChannelHuawei, q.Huawei)
+		b.channel(typ, funnel.PlatformQuickApp, funnel.ChannelOPPO, q.OPPO)
+	}
+	if h := s.SubHmos; h != nil {
+		b.channel(typ, funnel.PlatformHMOS, funnel.ChannelHmpns, h.Hmpns)
+		b.channel(typ, funnel.PlatformHMOS, funnel.ChannelJiguang, h.Jiguang)
+	}
+}
+
+func format(v *uint64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(*v, 10)
+}
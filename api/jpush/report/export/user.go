@@ -0,0 +1,98 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report/funnel"
+)
+
+// 用户统计导出的列名，依次为：
+//   - time：统计时间，按时间单位格式化（API 时区），如 "2024-06-11 09"（HOUR）、"2024-06-11"（DAY）、"2024-06"（MONTH）；
+//   - time_unit：时间单位，HOUR、DAY 或 MONTH；
+//   - platform：平台，如 funnel.PlatformAndroid、funnel.PlatformIOS、funnel.PlatformQuickApp、funnel.PlatformHMOS；
+//   - new、online、active：新增、在线、活跃用户数，未返回的指标为空（JSONL 中为 null）。
+var UserColumns = []string{"time", "time_unit", "platform", "new", "online", "active"}
+
+// # 用户统计导出行
+//
+// 每行对应一个统计时间在某个平台下的指标。
+type UserRow struct {
+	Time     string  `json:"time"`
+	TimeUnit string  `json:"time_unit"`
+	Platform string  `json:"platform"`
+	New      *uint64 `json:"new"`
+	Online   *uint64 `json:"online"`
+	Active   *uint64 `json:"active"`
+}
+
+// 按 UserColumns 的顺序返回各列的值。
+func (r UserRow) Record() []string {
+	return []string{r.Time, r.TimeUnit, r.Platform, format(r.New), format(r.Online), format(r.Active)}
+}
+
+// # 用户统计导出行列表
+type UserRows []UserRow
+
+// 以 CSV 格式（含表头，列见 UserColumns）写入 `w`。
+func (rows UserRows) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(UserColumns); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.Record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// 以 JSON Lines 格式（每行一个 JSON 对象，字段见 UserColumns）写入 `w`。
+func (rows UserRows) WriteJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for _, r := range rows {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 展开「用户统计」或「分组用户统计」的统计数据项（如 UserDetailGetResult.Items），未返回数据的平台不输出。
+func FromUserStats(items []report.UserStatsItem) UserRows {
+	var rows UserRows
+	for _, item := range items {
+		t, tu := item.Time.Format(), item.Time.TimeUnit.String()
+		for _, p := range []struct {
+			platform string
+			d        *report.UserStatsItemDetail
+		}{
+			{funnel.PlatformAndroid, item.Android}, {funnel.PlatformIOS, item.IOS},
+			{funnel.PlatformQuickApp, item.QuickApp}, {funnel.PlatformHMOS, item.HMOS},
+		} {
+			if p.d == nil || (p.d.New == nil && p.d.Online == nil && p.d.Active == nil) {
+				continue
+			}
+			rows = append(rows, UserRow{Time: t, TimeUnit: tu, Platform: p.platform, New: p.d.New, Online: p.d.Online, Active: p.d.Active})
+		}
+	}
+	return rows
+}
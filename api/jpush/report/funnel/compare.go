@@ -0,0 +1,141 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funnel
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// # 推送漏斗转化率
+//
+// 分母为 0 时对应的转化率为 0。
+type Rates struct {
+	SendRate     float64 `json:"send_rate"`     // 发送率：发送数量 / 有效目标
+	DeliveryRate float64 `json:"delivery_rate"` // 送达率：送达数量 / 发送数量
+	DisplayRate  float64 `json:"display_rate"`  // 展示率：展示数量 / 送达数量
+	CTR          float64 `json:"ctr"`           // 点击率：点击数量 / 展示数量；没有展示数据时为点击数量 / 送达数量
+	Conversion   float64 `json:"conversion"`    // 整体转化率：点击数量 / 有效目标
+}
+
+// 计算各阶段的转化率。
+func (c Counts) Rates() Rates {
+	ctrBase := c.Display
+	if ctrBase == 0 {
+		ctrBase = c.Received
+	}
+	return Rates{
+		SendRate:     ratio(c.Sent, c.Target),
+		DeliveryRate: ratio(c.Received, c.Sent),
+		DisplayRate:  ratio(c.Display, c.Received),
+		CTR:          ratio(c.Click, ctrBase),
+		Conversion:   ratio(c.Click, c.Target),
+	}
+}
+
+// 计算汇总数量的转化率。
+func (f *Funnel) Rates() Rates {
+	if f == nil {
+		return Rates{}
+	}
+	return f.Counts.Rates()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 推送漏斗对比行
+type ComparisonRow struct {
+	MsgID  string `json:"msg_id"`           // 推送消息 ID
+	Source string `json:"source,omitempty"` // 数据来源
+	Counts        // 数量，按 Comparison 的平台和发送通道筛选
+	Rates  Rates  `json:"rates"` // 转化率
+	Delta  Rates  `json:"delta"` // 与基准（第一条消息）的转化率差值
+}
+
+// # 推送漏斗对比
+//
+// 用于活动复盘时并排比较多条消息的推送漏斗，以第一条消息为基准计算转化率差值。
+type Comparison struct {
+	Platform string          `json:"platform,omitempty"` // 筛选的平台，为空时表示不限
+	Channel  string          `json:"channel,omitempty"`  // 筛选的发送通道，为空时表示不限
+	Rows     []ComparisonRow `json:"rows"`               // 各消息的对比行，顺序与输入一致
+}
+
+// 并排比较多条消息的汇总推送漏斗，nil 的推送漏斗会被忽略。
+func Compare(funnels ...*Funnel) *Comparison {
+	return compare("", "", funnels, func(f *Funnel) Counts { return f.Counts })
+}
+
+// 并排比较多条消息在指定平台和发送通道下的推送漏斗，`platform` 或 `channel` 为空时表示不限。
+func CompareChannel(platform, channel string, funnels ...*Funnel) *Comparison {
+	return compare(platform, channel, funnels, func(f *Funnel) Counts { return f.Channel(platform, channel) })
+}
+
+func compare(platform, channel string, funnels []*Funnel, counts func(*Funnel) Counts) *Comparison {
+	c := &Comparison{Platform: platform, Channel: channel}
+	for _, f := range funnels {
+		if f == nil {
+			continue
+		}
+		cnt := counts(f)
+		row := ComparisonRow{MsgID: f.MsgID, Source: f.Source, Counts: cnt, Rates: cnt.Rates()}
+		if len(c.Rows) > 0 {
+			base := c.Rows[0].Rates
+			row.Delta = Rates{
+				SendRate:     row.Rates.SendRate - base.SendRate,
+				DeliveryRate: row.Rates.DeliveryRate - base.DeliveryRate,
+				DisplayRate:  row.Rates.DisplayRate - base.DisplayRate,
+				CTR:          row.Rates.CTR - base.CTR,
+				Conversion:   row.Rates.Conversion - base.Conversion,
+			}
+		}
+		c.Rows = append(c.Rows, row)
+	}
+	return c
+}
+
+// 以文本表格展示对比结果，转化率以百分比表示，括号内为与基准的差值。
+func (c *Comparison) String() string {
+	if c == nil {
+		return ""
+	}
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "msg_id\tsource\ttarget\tsent\treceived\tdisplay\tclick\tdelivery\tctr\tconversion")
+	for i, r := range c.Rows {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", r.MsgID, r.Source,
+			r.Target, r.Sent, r.Received, r.Display, r.Click,
+			percent(r.Rates.DeliveryRate, r.Delta.DeliveryRate, i > 0),
+			percent(r.Rates.CTR, r.Delta.CTR, i > 0),
+			percent(r.Rates.Conversion, r.Delta.Conversion, i > 0))
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+func percent(rate, delta float64, withDelta bool) string {
+	if !withDelta {
+		return fmt.Sprintf("%.2f%%", rate*100)
+	}
+	return fmt.Sprintf("%.2f%% (%+.2f)", rate*100, delta*100)
+}
+
+func ratio(n, d uint64) float64 {
+	if d == 0 {
+		return 0
+	}
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,218 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funnel
+
+import "github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+
+// 消息类型。
+const (
+	TypeNotification = "notification"  // 通知栏消息
+	TypeMessage      = "message"       // 自定义消息
+	TypeInApp        = "inapp"         // 应用内提醒消息
+	TypeLiveActivity = "live_activity" // 实时活动消息
+)
+
+// 平台。
+const (
+	PlatformAll      = "all"      // 不区分平台（如极光通道的送达数包含 Android 和 iOS）
+	PlatformAndroid  = "android"  // Android 平台
+	PlatformIOS      = "ios"      // iOS 平台
+	PlatformQuickApp = "quickapp" // 快应用平台
+	PlatformHMOS     = "hmos"     // 鸿蒙平台
+)
+
+// 发送通道。
+const (
+	ChannelJiguang = "jiguang" // 极光通道
+	ChannelPns     = "pns"     // 厂商通道（未细分厂商）
+	ChannelXiaomi  = "xiaomi"  // 小米
+	ChannelHuawei  = "huawei"  // 华为
+	ChannelHonor   = "honor"   // 荣耀
+	ChannelMeizu   = "meizu"   // 魅族
+	ChannelOPPO    = "oppo"    // OPPO
+	ChannelVivo    = "vivo"    // vivo
+	ChannelASUS    = "asus"    // 华硕
+	ChannelFCM     = "fcm"     // FCM
+	ChannelTuibida = "tuibida" // 推必达
+	ChannelNIO     = "nio"     // 蔚来
+	ChannelVoIP    = "voip"    // VoIP
+	ChannelAPNs    = "apns"    // APNs
+	ChannelHmpns   = "hmpns"   // 鸿蒙 HMPNs
+)
+
+// 数据来源。
+const (
+	SourceDetail   = "detail"   // 消息统计详情，2021.09.01 新体系指标
+	SourceLegacy   = "legacy"   // 消息统计详情，2021.09.01 前旧体系指标
+	SourceReceived = "received" // 送达统计详情
+	SourceGroup    = "group"    // 分组消息统计详情
+)
+
+// # 推送漏斗各阶段数量
+//
+// 未返回的指标记为 0。
+type Counts struct {
+	Target   uint64 `json:"target"`   // 有效目标
+	Sent     uint64 `json:"sent"`     // 发送数量
+	Received uint64 `json:"received"` // 送达数量
+	Display  uint64 `json:"display"`  // 展示数量
+	Click    uint64 `json:"click"`    // 点击数量
+}
+
+func (c *Counts) add(o Counts) {
+	c.Target += o.Target
+	c.Sent += o.Sent
+	c.Received += o.Received
+	c.Display += o.Display
+	c.Click += o.Click
+}
+
+// # 按消息类型、平台和发送通道划分的推送漏斗
+type Channel struct {
+	Type     string `json:"type,omitempty"` // 消息类型，如 TypeNotification；来源于送达统计详情时可能为空
+	Platform string `json:"platform"`       // 平台，如 PlatformAndroid
+	Channel  string `json:"channel"`        // 发送通道，如 ChannelXiaomi
+	Counts
+}
+
+// # 推送漏斗
+//
+// 将一条推送消息的统计数据规整为 “有效目标 → 发送 → 送达 → 展示 → 点击” 的漏斗，并按消息类型、平台和发送通道细分。
+type Funnel struct {
+	MsgID    string    `json:"msg_id"`           // 推送消息 ID
+	Source   string    `json:"source,omitempty"` // 数据来源，如 SourceDetail、SourceLegacy
+	Counts             // 汇总数量
+	Channels []Channel `json:"channels,omitempty"` // 按消息类型、平台和发送通道细分的数量
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 将「消息统计详情」的 2021.09.01 新体系指标转换为推送漏斗，`d` 为 nil 时返回 nil。
+//
+// 汇总数量为各消息类型汇总统计之和。
+func FromMessageDetail(d *report.MessageDetail) *Funnel {
+	if d == nil {
+		return nil
+	}
+	f := &Funnel{MsgID: d.MsgID, Source: SourceDetail}
+	if d.Details == nil {
+		return f
+	}
+	f.addMessageStats(TypeNotification, d.Details.Notification)
+	f.addMessageStats(TypeMessage, d.Details.CustomMessage)
+	f.addMessageStats(TypeInApp, d.Details.InApp)
+	if la := d.Details.LiveActivity; la != nil {
+		f.Counts.add(Counts{Target: val(la.Target), Sent: val(la.Sent), Received: val(la.Received), Display: val(la.Display), Click: val(la.Click)})
+		f.addChannel(TypeLiveActivity, PlatformIOS, ChannelAPNs, la.SubIos)
+	}
+	return f
+}
+
+// 将「送达统计详情」转换为推送漏斗，`d` 为 nil 时返回 nil。
+//
+// 送达统计详情只包含发送和送达指标，汇总数量为各通道之和（极光通道的送达数已包含 iOS 自定义消息送达数）。
+func FromReceivedDetail(d *report.ReceivedDetail) *Funnel {
+	if d == nil {
+		return nil
+	}
+	f := &Funnel{MsgID: d.MsgID, Source: SourceReceived}
+	add := func(typ, platform, channel string, sent, received *uint64) {
+		if sent == nil && received == nil {
+			return
+		}
+		c := Channel{Type: typ, Platform: platform, Channel: channel, Counts: Counts{Sent: val(sent), Received: val(received)}}
+		f.Channels = append(f.Channels, c)
+		f.Counts.add(c.Counts)
+	}
+	add("", PlatformAll, ChannelJiguang, nil, d.JPushReceived)
+	add("", PlatformAndroid, ChannelPns, d.AndroidPnsSent, d.AndroidPnsReceived)
+	add(TypeNotification, PlatformIOS, ChannelAPNs, d.IOSApnsSent, d.IOSApnsReceived)
+	add(TypeLiveActivity, PlatformIOS, ChannelAPNs, d.LiveActivitySent, d.LiveActivityReceived)
+	add("", PlatformQuickApp, ChannelPns, d.QuickAppPnsSent, nil)
+	add("", PlatformQuickApp, ChannelJiguang, nil, d.QuickAppJPushReceived)
+	add(TypeNotification, PlatformHMOS, ChannelHmpns, d.HmosHmpnsSent, d.HmosHmpnsReceived)
+	add(TypeMessage, PlatformHMOS, ChannelHmpns, d.HmosMsgSent, d.HmosMsgReceived)
+	return f
+}
+
+// 获取指定平台和发送通道的数量（合并所有消息类型），`platform` 或 `channel` 为空时表示不限。
+func (f *Funnel) Channel(platform, channel string) Counts {
+	var c Counts
+	if f == nil {
+		return c
+	}
+	for _, ch := range f.Channels {
+		if (platform == "" || ch.Platform == platform) && (channel == "" || ch.Channel == channel) {
+			c.add(ch.Counts)
+		}
+	}
+	return c
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (f *Funnel) addMessageStats(typ string, s *report.MessageStats) {
+	if s == nil {
+		return
+	}
+	f.Counts.add(Counts{Target: val(s.Target), Sent: val(s.Sent), Received: val(s.Received), Display: val(s.Display), Click: val(s.Click)})
+	if a := s.SubAndroid; a != nil {
+		f.addChannel(typ, PlatformAndroid, ChannelJiguang, a.Jiguang)
+		f.addChannel(typ, PlatformAndroid, ChannelXiaomi, a.Xiaomi)
+		f.addChannel(typ, PlatformAndroid, ChannelHuawei, a.Huawei)
+		f.addChannel(typ, PlatformAndroid, ChannelHonor, a.Honor)
+		f.addChannel(typ, PlatformAndroid, ChannelMeizu, a.Meizu)
+		f.addChannel(typ, PlatformAndroid, ChannelOPPO, a.OPPO)
+		f.addChannel(typ, PlatformAndroid, ChannelVivo, a.Vivo)
+		f.addChannel(typ, PlatformAndroid, ChannelASUS, a.ASUS)
+		f.addChannel(typ, PlatformAndroid, ChannelFCM, a.FCM)
+		f.addChannel(typ, PlatformAndroid, ChannelTuibida, a.Tuibida)
+		f.addChannel(typ, PlatformAndroid, ChannelNIO, a.NIO)
+	}
+	if i := s.SubIos; i != nil {
+		f.addChannel(typ, PlatformIOS, ChannelJiguang, i.Jiguang)
+		f.addChannel(typ, PlatformIOS, ChannelVoIP, i.VoIP)
+		f.addChannel(typ, PlatformIOS, ChannelAPNs, i.APNs)
+	}
+	if q := s.SubQuickApp; q != nil {
+		f.addChannel(typ, PlatformQuickApp, ChannelJiguang, q.Jiguang)
+		f.addChannel(typ, PlatformQuickApp, ChannelXiaomi, q.Xiaomi)
+		f.addChannel(typ, PlatformQuickApp, ChannelHuawei, q.Huawei)
+		f.addChannel(typ, PlatformQuickApp, ChannelOPPO, q.OPPO)
+	}
+	if h := s.SubHmos; h != nil {
+		f.addChannel(typ, PlatformHMOS, ChannelHmpns, h.Hmpns)
+		f.addChannel(typ, PlatformHMOS, ChannelJiguang, h.Jiguang)
+	}
+}
+
+func (f *Funnel) addChannel(typ, platform, channel string, s *report.ChannelStats) {
+	if s == nil {
+		return
+	}
+	f.Channels = append(f.Channels, Channel{
+		Type:     typ,
+		Platform: platform,
+		Channel:  channel,
+		Counts:   Counts{Target: val(s.Target), Sent: val(s.Sent), Received: val(s.Received), Display: val(s.Display), Click: val(s.Click)},
+	})
+}
+
+func val(v *uint64) uint64 {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
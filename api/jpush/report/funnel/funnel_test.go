@@ -0,0 +1,251 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funnel_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report/funnel"
+)
+
+func u(v uint64) *uint64 { return &v }
+
+func TestCountsRates(t *testing.T) {
+	tests := []struct {
+		name   string
+		counts funnel.Counts
+		want   funnel.Rates
+	}{
+		{
+			name: "empty",
+			want: funnel.Rates{},
+		},
+		{
+			name:   "full funnel",
+			counts: funnel.Counts{Target: 200, Sent: 100, Received: 80, Display: 40, Click: 10},
+			want:   funnel.Rates{SendRate: 0.5, DeliveryRate: 0.8, DisplayRate: 0.5, CTR: 0.25, Conversion: 0.05},
+		},
+		{
+			name:   "ctr falls back to received without display",
+			counts: funnel.Counts{Target: 100, Sent: 100, Received: 50, Click: 10},
+			want:   funnel.Rates{SendRate: 1, DeliveryRate: 0.5, CTR: 0.2, Conversion: 0.1},
+		},
+		{
+			name:   "zero denominators",
+			counts: funnel.Counts{Click: 5},
+			want:   funnel.Rates{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertRates(t, tt.counts.Rates(), tt.want)
+		})
+	}
+
+	var f *funnel.Funnel
+	if got := f.Rates(); got != (funnel.Rates{}) {
+		t.Errorf("nil Funnel.Rates() = %+v, want zero", got)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	a := &funnel.Funnel{
+		MsgID:  "1",
+		Source: funnel.SourceDetail,
+		Counts: funnel.Counts{Target: 100, Sent: 100, Received: 50, Click: 5},
+		Channels: []funnel.Channel{
+			{Platform: funnel.PlatformAndroid, Channel: funnel.ChannelXiaomi, Counts: funnel.Counts{Target: 60, Sent: 60, Received: 40, Click: 4}},
+			{Platform: funnel.PlatformIOS, Channel: funnel.ChannelAPNs, Counts: funnel.Counts{Target: 40, Sent: 40, Received: 10, Click: 1}},
+		},
+	}
+	b := &funnel.Funnel{
+		MsgID:  "2",
+		Source: funnel.SourceLegacy,
+		Counts: funnel.Counts{Target: 100, Sent: 100, Received: 80, Click: 20},
+		Channels: []funnel.Channel{
+			{Platform: funnel.PlatformAndroid, Channel: funnel.ChannelXiaomi, Counts: funnel.Counts{Target: 100, Sent: 100, Received: 80, Click: 20}},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		c         *funnel.Comparison
+		wantIDs   []string
+		wantRates []funnel.Rates
+		wantDelta []funnel.Rates
+	}{
+		{
+			name:      "empty",
+			c:         funnel.Compare(),
+			wantIDs:   nil,
+			wantRates: nil,
+			wantDelta: nil,
+		},
+		{
+			name:    "summary with nil skipped",
+			c:       funnel.Compare(nil, a, nil, b),
+			wantIDs: []string{"1", "2"},
+			wantRates: []funnel.Rates{
+				{SendRate: 1, DeliveryRate: 0.5, CTR: 0.1, Conversion: 0.05},
+				{SendRate: 1, DeliveryRate: 0.8, CTR: 0.25, Conversion: 0.2},
+			},
+			wantDelta: []funnel.Rates{
+				{},
+				{DeliveryRate: 0.3, CTR: 0.15, Conversion: 0.15},
+			},
+		},
+		{
+			name:    "filtered by channel",
+			c:       funnel.CompareChannel(funnel.PlatformAndroid, funnel.ChannelXiaomi, a, b),
+			wantIDs: []string{"1", "2"},
+			wantRates: []funnel.Rates{
+				{SendRate: 1, DeliveryRate: 40.0 / 60, CTR: 0.1, Conversion: 4.0 / 60},
+				{SendRate: 1, DeliveryRate: 0.8, CTR: 0.25, Conversion: 0.2},
+			},
+			wantDelta: []funnel.Rates{
+				{},
+				{DeliveryRate: 0.8 - 40.0/60, CTR: 0.15, Conversion: 0.2 - 4.0/60},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.c.Rows) != len(tt.wantIDs) {
+				t.Fatalf("got %d rows, want %d", len(tt.c.Rows), len(tt.wantIDs))
+			}
+			for i, row := range tt.c.Rows {
+				if row.MsgID != tt.wantIDs[i] {
+					t.Errorf("row %d: MsgID = %q, want %q", i, row.MsgID, tt.wantIDs[i])
+				}
+				assertRates(t, row.Rates, tt.wantRates[i])
+				assertRates(t, row.Delta, tt.wantDelta[i])
+			}
+		})
+	}
+}
+
+func TestFromLegacy(t *testing.T) {
+	tests := []struct {
+		name     string
+		detail   *report.MessageDetail
+		want     funnel.Counts
+		channels []funnel.Channel
+	}{
+		{
+			name:   "no data",
+			detail: &report.MessageDetail{MsgID: "1"},
+		},
+		{
+			name: "jpush sent falls back to online push and clicks are summed",
+			detail: &report.MessageDetail{
+				MsgID: "1",
+				JPush: &report.LegacyJPush{Target: u(100), OnlinePush: u(90), Received: u(80), Click: u(5), MsgClick: u(3)},
+			},
+			want: funnel.Counts{Target: 100, Sent: 90, Received: 80, Click: 8},
+			channels: []funnel.Channel{
+				{Platform: funnel.PlatformAll, Channel: funnel.ChannelJiguang, Counts: funnel.Counts{Target: 100, Sent: 90, Received: 80, Click: 8}},
+			},
+		},
+		{
+			name: "jpush sent preferred over online push",
+			detail: &report.MessageDetail{
+				MsgID: "1",
+				JPush: &report.LegacyJPush{Target: u(100), OnlinePush: u(90), Sent: u(95)},
+			},
+			want: funnel.Counts{Target: 100, Sent: 95},
+			channels: []funnel.Channel{
+				{Platform: funnel.PlatformAll, Channel: funnel.ChannelJiguang, Counts: funnel.Counts{Target: 100, Sent: 95}},
+			},
+		},
+		{
+			name: "android pns without vendor details",
+			detail: &report.MessageDetail{
+				MsgID:      "1",
+				AndroidPns: &report.LegacyAndroidPns{PnsTarget: u(50), PnsSent: u(40), PnsReceived: u(30), PnsDisplay: u(20)},
+			},
+			want: funnel.Counts{Target: 50, Sent: 40, Received: 30, Display: 20},
+			channels: []funnel.Channel{
+				{Platform: funnel.PlatformAndroid, Channel: funnel.ChannelPns, Counts: funnel.Counts{Target: 50, Sent: 40, Received: 30, Display: 20}},
+			},
+		},
+		{
+			name: "android pns vendor details replace the summary",
+			detail: &report.MessageDetail{
+				MsgID: "1",
+				AndroidPns: &report.LegacyAndroidPns{
+					PnsTarget:    u(50),
+					PnsSent:      u(40),
+					XiaomiDetail: &report.LegacyChannelStats{Target: u(30), Sent: u(25)},
+					HuaweiDetail: &report.LegacyChannelStats{Target: u(20), Sent: u(15), Received: u(10)},
+				},
+			},
+			want: funnel.Counts{Target: 50, Sent: 40, Received: 10},
+			channels: []funnel.Channel{
+				{Platform: funnel.PlatformAndroid, Channel: funnel.ChannelXiaomi, Counts: funnel.Counts{Target: 30, Sent: 25}},
+				{Platform: funnel.PlatformAndroid, Channel: funnel.ChannelHuawei, Counts: funnel.Counts{Target: 20, Sent: 15, Received: 10}},
+			},
+		},
+		{
+			name: "ios apns only and quick app channels",
+			detail: &report.MessageDetail{
+				MsgID:         "1",
+				IOS:           &report.LegacyIos{ApnsTarget: u(10), ApnsSent: u(9), ApnsReceived: u(8), ApnsDisplay: u(7), ApnsClick: u(2), MsgTarget: u(100), MsgReceived: u(100)},
+				QuickAppJPush: &report.LegacyQuickAppJPush{Target: u(5), Received: u(4), Click: u(1), MsgClick: u(1)},
+				QuickAppPns:   &report.LegacyQuickAppPns{PnsTarget: u(3), PnsSent: u(3)},
+			},
+			want: funnel.Counts{Target: 18, Sent: 12, Received: 12, Display: 7, Click: 4},
+			channels: []funnel.Channel{
+				{Type: funnel.TypeNotification, Platform: funnel.PlatformIOS, Channel: funnel.ChannelAPNs, Counts: funnel.Counts{Target: 10, Sent: 9, Received: 8, Display: 7, Click: 2}},
+				{Platform: funnel.PlatformQuickApp, Channel: funnel.ChannelJiguang, Counts: funnel.Counts{Target: 5, Received: 4, Click: 2}},
+				{Platform: funnel.PlatformQuickApp, Channel: funnel.ChannelPns, Counts: funnel.Counts{Target: 3, Sent: 3}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := funnel.FromLegacy(tt.detail)
+			if f.MsgID != tt.detail.MsgID || f.Source != funnel.SourceLegacy {
+				t.Errorf("got MsgID %q, Source %q", f.MsgID, f.Source)
+			}
+			if f.Counts != tt.want {
+				t.Errorf("Counts = %+v, want %+v", f.Counts, tt.want)
+			}
+			if !reflect.DeepEqual(f.Channels, tt.channels) {
+				t.Errorf("Channels = %+v, want %+v", f.Channels, tt.channels)
+			}
+
+			// Normalize 在没有新体系指标时应与 FromLegacy 一致。
+			if n := funnel.Normalize(tt.detail); !reflect.DeepEqual(n, f) {
+				t.Errorf("Normalize() = %+v, want %+v", n, f)
+			}
+		})
+	}
+
+	if f := funnel.FromLegacy(nil); f != nil {
+		t.Errorf("FromLegacy(nil) = %+v, want nil", f)
+	}
+}
+
+func assertRates(t *testing.T, got, want funnel.Rates) {
+	t.Helper()
+	eq := func(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+	if !eq(got.SendRate, want.SendRate) || !eq(got.DeliveryRate, want.DeliveryRate) || !eq(got.DisplayRate, want.DisplayRate) ||
+		!eq(got.CTR, want.CTR) || !eq(got.Conversion, want.Conversion) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
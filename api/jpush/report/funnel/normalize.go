@@ -0,0 +1,177 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package funnel
+
+import (
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/greport"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+)
+
+// # 规整消息统计详情
+//
+// 根据消息的推送时间，「消息统计详情」的数据可能在 2021.09.01 新体系指标（Details）或旧体系指标（JPush、AndroidPns、IOS 等）中，
+// Normalize 优先使用有数据的新体系指标，否则使用旧体系指标，得到统一的推送漏斗（可通过 Funnel.Source 区分来源），`d` 为 nil 时返回 nil。
+func Normalize(d *report.MessageDetail) *Funnel {
+	if d == nil {
+		return nil
+	}
+	if hasDetails(d.Details) {
+		return FromMessageDetail(d)
+	}
+	return FromLegacy(d)
+}
+
+// 批量规整消息统计详情，如 MessageDetailGetResult.MessageDetails。
+func NormalizeAll(details []report.MessageDetail) []*Funnel {
+	funnels := make([]*Funnel, len(details))
+	for i := range details {
+		funnels[i] = Normalize(&details[i])
+	}
+	return funnels
+}
+
+// 将「消息统计详情」的 2021.09.01 前旧体系指标转换为推送漏斗，通道的划分见 WalkLegacy，`d` 为 nil 时返回 nil。
+//
+// 汇总数量为各通道之和。
+func FromLegacy(d *report.MessageDetail) *Funnel {
+	if d == nil {
+		return nil
+	}
+	f := &Funnel{MsgID: d.MsgID, Source: SourceLegacy}
+	WalkLegacy(d, f.addCounts)
+	return f
+}
+
+// 将「分组消息统计详情」转换为推送漏斗（MsgID 为分组推送消息 ID），通道的划分见 WalkGroupMessageDetail，`d` 为 nil 时返回 nil。
+func FromGroupMessageDetail(d *greport.MessageDetail) *Funnel {
+	if d == nil {
+		return nil
+	}
+	f := &Funnel{MsgID: d.GroupMsgID, Source: SourceGroup}
+	WalkGroupMessageDetail(d, f.addCounts)
+	return f
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 通道访问函数，依次传入通道的消息类型、平台、发送通道及各项指标，未返回的指标为 nil。
+type ChannelFunc func(typ, platform, channel string, target, sent, received, display, click *uint64)
+
+// # 遍历旧体系指标
+//
+// 按推送漏斗的口径划分「消息统计详情」的 2021.09.01 前旧体系指标，对每个通道调用 `fn`，FromLegacy 与 export 包均以此为准。
+//
+// 旧体系指标不区分消息类型，划分规则：
+//   - 极光通道（JPush）：平台为 PlatformAll，缺少发送数量时以在线推送数代替，点击数为通知点击数与自定义消息点击数之和，已包含 iOS 自定义消息；
+//   - Android 厂商通道（AndroidPns）：有厂商细分详情时按厂商输出，否则输出未细分的 ChannelPns；旧体系没有厂商通道点击数；
+//   - iOS（IOS）：仅输出 APNs 通知，iOS 自定义消息已计入极光通道；
+//   - 快应用（QuickAppJPush、QuickAppPns）：分别输出极光通道与厂商通道，极光通道的点击数同样为两者之和。
+func WalkLegacy(d *report.MessageDetail, fn ChannelFunc) {
+	if d == nil {
+		return
+	}
+	walkLegacyJPush(d.JPush, fn)
+	walkLegacyAndroidPns(d.AndroidPns, fn)
+	if i := d.IOS; i != nil {
+		fn(TypeNotification, PlatformIOS, ChannelAPNs, i.ApnsTarget, i.ApnsSent, i.ApnsReceived, i.ApnsDisplay, i.ApnsClick)
+	}
+	if q := d.QuickAppJPush; q != nil {
+		fn("", PlatformQuickApp, ChannelJiguang, q.Target, nil, q.Received, nil, sum(q.Click, q.MsgClick))
+	}
+	if q := d.QuickAppPns; q != nil {
+		fn("", PlatformQuickApp, ChannelPns, q.PnsTarget, q.PnsSent, nil, nil, nil)
+	}
+}
+
+// # 遍历分组消息统计详情
+//
+// 极光通道、Android 厂商通道和 iOS 的划分规则同 WalkLegacy，鸿蒙平台分别输出通知与自定义消息。
+func WalkGroupMessageDetail(d *greport.MessageDetail, fn ChannelFunc) {
+	if d == nil {
+		return
+	}
+	walkLegacyJPush(d.JPush, fn)
+	walkLegacyAndroidPns(d.AndroidPns, fn)
+	if i := d.IOS; i != nil {
+		fn(TypeNotification, PlatformIOS, ChannelAPNs, i.ApnsTarget, i.ApnsSent, i.ApnsReceived, i.ApnsDisplay, i.ApnsClick)
+	}
+	if h := d.HMOS; h != nil {
+		fn(TypeNotification, PlatformHMOS, ChannelHmpns, h.HmpnsTarget, h.HmpnsSent, h.HmpnsReceived, nil, h.HmpnsClick)
+		fn(TypeMessage, PlatformHMOS, ChannelHmpns, h.MsgTarget, nil, h.MsgReceived, nil, h.MsgClick)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func walkLegacyJPush(j *report.LegacyJPush, fn ChannelFunc) {
+	if j != nil {
+		sent := j.Sent
+		if sent == nil {
+			sent = j.OnlinePush // 早期数据没有发送数量，以在线推送数代替
+		}
+		fn("", PlatformAll, ChannelJiguang, j.Target, sent, j.Received, j.Display, sum(j.Click, j.MsgClick))
+	}
+}
+
+func walkLegacyAndroidPns(p *report.LegacyAndroidPns, fn ChannelFunc) {
+	if p == nil {
+		return
+	}
+	vendors := []struct {
+		channel string
+		s       *report.LegacyChannelStats
+	}{
+		{ChannelXiaomi, p.XiaomiDetail}, {ChannelHuawei, p.HuaweiDetail}, {ChannelHonor, p.HonorDetail},
+		{ChannelMeizu, p.MeizuDetail}, {ChannelOPPO, p.OppoDetail}, {ChannelVivo, p.VivoDetail},
+		{ChannelASUS, p.AsusDetail}, {ChannelFCM, p.FcmDetail}, {ChannelNIO, p.NioDetail},
+	}
+	detailed := false
+	for _, v := range vendors {
+		if v.s != nil {
+			detailed = true
+			fn("", PlatformAndroid, v.channel, v.s.Target, v.s.Sent, v.s.Received, v.s.Display, nil)
+		}
+	}
+	if !detailed {
+		fn("", PlatformAndroid, ChannelPns, p.PnsTarget, p.PnsSent, p.PnsReceived, p.PnsDisplay, nil)
+	}
+}
+
+// 添加一个通道并计入汇总数量，所有指标均未返回时忽略。
+func (f *Funnel) addCounts(typ, platform, channel string, target, sent, received, display, click *uint64) {
+	if target == nil && sent == nil && received == nil && display == nil && click == nil {
+		return
+	}
+	c := Channel{
+		Type:     typ,
+		Platform: platform,
+		Channel:  channel,
+		Counts:   Counts{Target: val(target), Sent: val(sent), Received: val(received), Display: val(display), Click: val(click)},
+	}
+	f.Channels = append(f.Channels, c)
+	f.Counts.add(c.Counts)
+}
+
+func hasDetails(d *report.Details) bool {
+	return d != nil && (d.Notification != nil || d.CustomMessage != nil || d.InApp != nil || d.LiveActivity != nil)
+}
+
+func sum(a, b *uint64) *uint64 {
+	if a == nil && b == nil {
+		return nil
+	}
+	s := val(a) + val(b)
+	return &s
+}
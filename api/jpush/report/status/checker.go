@@ -0,0 +1,329 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+var ErrNoTargets = errors.New("no registration id or alias to check") // 没有需要查询的 Registration ID 或别名
+
+// # 分块查询失败信息
+type ChunkError struct {
+	RegistrationIDs []string // 查询失败的 Registration ID 列表
+	Err             error    // 失败原因
+}
+
+// # 别名解析失败信息
+type AliasError struct {
+	Alias string // 别名
+	Err   error  // 失败原因
+}
+
+// # 批量查询结果
+type Result struct {
+	MsgID       string                          // 推送消息 ID
+	Status      map[string]report.MessageStatus // 合并后的送达状态，key 为 Registration ID
+	Missing     []string                        // 查询成功但未返回送达状态的 Registration ID 列表（已排序）
+	Aliases     map[string][]string             // 别名解析结果，key 为别名，仅 CheckAliases 返回
+	ChunkErrors []ChunkError                    // 查询失败的分块，可使用其中的 Registration ID 重试
+	AliasErrors []AliasError                    // 解析失败的别名，仅 CheckAliases 返回
+}
+
+// 是否所有分块均查询成功、所有别名均解析成功。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && len(rs.ChunkErrors) == 0 && len(rs.AliasErrors) == 0
+}
+
+// 各送达状态的设备数量。
+func (rs *Result) Histogram() map[report.MessageStatus]int {
+	histogram := make(map[report.MessageStatus]int)
+	if rs == nil {
+		return histogram
+	}
+	for _, s := range rs.Status {
+		histogram[s]++
+	}
+	return histogram
+}
+
+// 查询失败的 Registration ID 列表，可用于重试。
+func (rs *Result) FailedRegistrationIDs() []string {
+	if rs == nil {
+		return nil
+	}
+	var regIDs []string
+	for _, e := range rs.ChunkErrors {
+		regIDs = append(regIDs, e.RegistrationIDs...)
+	}
+	sort.Strings(regIDs)
+	return regIDs
+}
+
+// # 导出为 CSV
+//
+// 列依次为：alias（仅 CheckAliases 有值）、registration_id、status_code、status。
+//   - 查询成功但未返回送达状态的设备，status_code 为空，status 为 "Missing"；
+//   - 查询失败的设备，status_code 为空，status 为 "Error"；
+//   - 未解析到设备的别名，registration_id 为空，status 为 "No Device" 或 "Error"（解析失败）。
+func (rs *Result) WriteCSV(w io.Writer) error {
+	if rs == nil {
+		return errors.New("nil result")
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"alias", "registration_id", "status_code", "status"}); err != nil {
+		return err
+	}
+
+	failed := make(map[string]bool)
+	for _, regID := range rs.FailedRegistrationIDs() {
+		failed[regID] = true
+	}
+	row := func(alias, regID string) []string {
+		if s, ok := rs.Status[regID]; ok {
+			return []string{alias, regID, strconv.Itoa(int(s)), s.String()}
+		}
+		if failed[regID] {
+			return []string{alias, regID, "", "Error"}
+		}
+		return []string{alias, regID, "", "Missing"}
+	}
+
+	if rs.Aliases != nil {
+		aliasErrs := make(map[string]bool, len(rs.AliasErrors))
+		for _, e := range rs.AliasErrors {
+			aliasErrs[e.Alias] = true
+		}
+		for _, alias := range sortedKeys(rs.Aliases) {
+			regIDs := rs.Aliases[alias]
+			if len(regIDs) == 0 {
+				status := "No Device"
+				if aliasErrs[alias] {
+					status = "Error"
+				}
+				if err := cw.Write([]string{alias, "", "", status}); err != nil {
+					return err
+				}
+				continue
+			}
+			for _, regID := range regIDs {
+				if err := cw.Write(row(alias, regID)); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		regIDs := make([]string, 0, len(rs.Status)+len(rs.Missing)+len(failed))
+		for regID := range rs.Status {
+			regIDs = append(regIDs, regID)
+		}
+		regIDs = append(regIDs, rs.Missing...)
+		for regID := range failed {
+			regIDs = append(regIDs, regID)
+		}
+		sort.Strings(regIDs)
+		for _, regID := range regIDs {
+			if err := cw.Write(row("", regID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 送达状态批量查询器
+//
+// 突破「送达状态查询」接口单次最多 1000 个 Registration ID 的限制：将任意数量的 Registration ID（或通过别名解析得到的 Registration ID）
+// 按 1000 个一组分块，在限速范围内并发查询，并合并各分块的结果。
+type Checker struct {
+	report report.APIv3
+	cfg    config
+}
+
+// 创建新的送达状态批量查询器。
+//   - reportAPI：【必填】统计 API v3 接口；
+//   - opts：【可选】查询器配置选项，通过别名查询时需要使用 WithDeviceAPI 配置设备 API v3 接口。
+func NewChecker(reportAPI report.APIv3, opts ...ConfigOption) (*Checker, error) {
+	if reportAPI == nil {
+		return nil, api.ErrNilJPushReportAPIv3
+	}
+
+	c := config{
+		logger:      api.DefaultJPushLogger,
+		concurrency: defaultConcurrency,
+		chunkSize:   maxRegIDsPerChunk,
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	if c.limiter == nil {
+		limiter, err := api.NewRateLimiter(defaultRateLimit, defaultRatePer)
+		if err != nil {
+			return nil, err
+		}
+		c.limiter = limiter
+	}
+	return &Checker{report: reportAPI, cfg: c}, nil
+}
+
+// 批量查询设备对消息 `msgID` 的送达状态（重复及空的 Registration ID 会被忽略）。
+//   - date：【可选】消息推送日期，同 report.APIv3 的 GetMessageStatus。
+//
+// 单个分块查询失败不会中止其余分块，失败的分块可从返回结果的 ChunkErrors 中获取。
+func (c *Checker) Check(ctx context.Context, msgID string, registrationIDs []string, date *jiguang.LocalDate) (*Result, error) {
+	if msgID == "" {
+		return nil, errors.New("`msgID` cannot be empty")
+	}
+	registrationIDs = api.DedupeStrings(registrationIDs)
+	if len(registrationIDs) == 0 {
+		return nil, ErrNoTargets
+	}
+
+	chunks := api.ChunkStrings(registrationIDs, c.cfg.chunkSize)
+	statuses := make([]map[string]report.MessageStatus, len(chunks))
+	errs := make([]error, len(chunks))
+	api.RunConcurrently(len(chunks), c.cfg.concurrency, func(i int) {
+		statuses[i], errs[i] = c.checkChunk(ctx, msgID, chunks[i], date)
+	})
+
+	result := &Result{MsgID: msgID, Status: make(map[string]report.MessageStatus, len(registrationIDs))}
+	for i, ids := range chunks {
+		if errs[i] != nil {
+			c.cfg.logger.Errorf(ctx, "查询消息 %[2]s 的 %[1]d 个设备的送达状态失败：%[3]s", len(ids), msgID, errs[i])
+			result.ChunkErrors = append(result.ChunkErrors, ChunkError{RegistrationIDs: ids, Err: errs[i]})
+			continue
+		}
+		for _, regID := range ids {
+			if s, ok := statuses[i][regID]; ok {
+				result.Status[regID] = s
+			} else {
+				result.Missing = append(result.Missing, regID)
+			}
+		}
+	}
+	sort.Strings(result.Missing)
+	c.cfg.logger.Infof(ctx, "消息 %s 送达状态查询完成：查询到 %d 个设备，%d 个设备无记录，%d 个分块查询失败",
+		msgID, len(result.Status), len(result.Missing), len(result.ChunkErrors))
+	return result, nil
+}
+
+// 通过别名批量查询设备对消息 `msgID` 的送达状态：先通过设备 API 的 GetAlias 解析别名绑定的 Registration ID，再调用 Check 查询。
+//
+// 解析失败的别名可从返回结果的 AliasErrors 中获取，未绑定设备的别名在 Aliases 中对应空列表。
+func (c *Checker) CheckAliases(ctx context.Context, msgID string, aliases []string, date *jiguang.LocalDate) (*Result, error) {
+	if c.cfg.device == nil {
+		return nil, api.ErrNilJPushDeviceAPIv3
+	}
+	if msgID == "" {
+		return nil, errors.New("`msgID` cannot be empty")
+	}
+	aliases = api.DedupeStrings(aliases)
+	if len(aliases) == 0 {
+		return nil, ErrNoTargets
+	}
+
+	resolved := make([][]string, len(aliases))
+	errs := make([]error, len(aliases))
+	api.RunConcurrently(len(aliases), c.cfg.concurrency, func(i int) {
+		resolved[i], errs[i] = c.resolveAlias(ctx, aliases[i])
+	})
+
+	byAlias := make(map[string][]string, len(aliases))
+	var (
+		regIDs    []string
+		aliasErrs []AliasError
+	)
+	for i, alias := range aliases {
+		byAlias[alias] = resolved[i]
+		if errs[i] != nil {
+			c.cfg.logger.Errorf(ctx, "解析别名 %s 失败：%s", alias, errs[i])
+			aliasErrs = append(aliasErrs, AliasError{Alias: alias, Err: errs[i]})
+			continue
+		}
+		regIDs = append(regIDs, resolved[i]...)
+	}
+
+	result := &Result{MsgID: msgID, Status: map[string]report.MessageStatus{}}
+	if len(api.DedupeStrings(regIDs)) > 0 {
+		var err error
+		if result, err = c.Check(ctx, msgID, regIDs, date); err != nil {
+			return nil, err
+		}
+	}
+	result.Aliases, result.AliasErrors = byAlias, aliasErrs
+	return result, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (c *Checker) checkChunk(ctx context.Context, msgID string, regIDs []string, date *jiguang.LocalDate) (map[string]report.MessageStatus, error) {
+	if err := c.cfg.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	result, err := c.report.GetMessageStatus(ctx, msgID, regIDs, date)
+	if err != nil {
+		return nil, err
+	}
+	c.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	return result.Status, nil
+}
+
+func (c *Checker) resolveAlias(ctx context.Context, alias string) ([]string, error) {
+	if err := c.cfg.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	result, err := c.cfg.device.GetAlias(ctx, alias)
+	if err != nil {
+		return nil, err
+	}
+	c.cfg.limiter.Observe(result.Rate)
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	regIDs := make([]string, 0, len(result.Data))
+	for _, d := range result.Data {
+		regIDs = append(regIDs, d.RegistrationID)
+	}
+	return regIDs, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
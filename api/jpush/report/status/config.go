@@ -0,0 +1,145 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package status
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/device"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultConcurrency = 4           // 默认的并发查询数
+	defaultRateLimit   = 5           // 默认每个限速周期内允许的查询请求数
+	defaultRatePer     = time.Second // 默认的限速周期
+	maxRegIDsPerChunk  = 1000        // 送达状态查询 API 每次最多查询的 Registration ID 数量
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 送达状态批量查询器配置。
+type config struct {
+	logger      jiguang.Logger   // 日志打印器，默认为 api.DefaultJPushLogger
+	device      device.APIv3     // 设备 API v3 接口，用于通过别名解析 Registration ID
+	concurrency int              // 并发查询数，默认为 4
+	limiter     *api.RateLimiter // API 调用限速器，默认为每秒 5 次
+	chunkSize   int              // 每次查询的 Registration ID 数量，默认为 1000
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 送达状态批量查询器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置送达状态批量查询器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 设备 API 配置选项。
+type deviceOption struct {
+	device device.APIv3
+}
+
+func (o deviceOption) apply(c *config) error {
+	if o.device == nil {
+		return errors.New("`deviceAPI` cannot be nil")
+	}
+	c.device = o.device
+	return nil
+}
+
+// 配置设备 API v3 接口，使用 CheckAliases 通过别名查询时必须配置。
+func WithDeviceAPI(deviceAPI device.APIv3) ConfigOption {
+	return deviceOption{deviceAPI}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发查询数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行的查询请求数（含别名解析请求），默认为 4。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 限速器配置选项。
+type rateLimiterOption struct {
+	limiter *api.RateLimiter
+}
+
+func (o rateLimiterOption) apply(c *config) error {
+	if o.limiter == nil {
+		return errors.New("`limiter` cannot be nil")
+	}
+	c.limiter = o.limiter
+	return nil
+}
+
+// 自定义配置查询请求（含别名解析请求）使用的限速器（详见 api.RateLimiter），默认为每秒 5 次。
+func WithRateLimiter(limiter *api.RateLimiter) ConfigOption {
+	return rateLimiterOption{limiter}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 分块大小配置选项。
+type chunkSizeOption int
+
+func (o chunkSizeOption) apply(c *config) error {
+	if o <= 0 || o > maxRegIDsPerChunk {
+		return errors.New("`chunkSize` must be in range [1, 1000]")
+	}
+	c.chunkSize = int(o)
+	return nil
+}
+
+// 自定义配置每次查询的 Registration ID 数量，取值范围为 [1, 1000]，默认为 1000。
+func WithChunkSize(n int) ConfigOption {
+	return chunkSizeOption(n)
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const (
+	defaultInitialInterval = 5 * time.Minute // 默认首次查询间隔
+	defaultMaxInterval     = time.Hour       // 默认最大查询间隔
+	defaultStableRounds    = 3               // 默认判定数据稳定所需的连续不变次数
+	defaultMinDuration     = time.Hour       // 默认最短跟踪时长
+	defaultMaxDuration     = 24 * time.Hour  // 默认最长跟踪时长
+	defaultMinRemaining    = 5               // 默认为其他调用方预留的 API 剩余可用次数
+	maxMsgIDsPerRequest    = 100             // 统计 API 每次最多查询的 msg_id 数量
+)
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 送达跟踪器配置。
+type config struct {
+	logger          jiguang.Logger       // 日志打印器，默认为 api.DefaultJPushLogger
+	initialInterval time.Duration        // 首次查询间隔，默认为 5 分钟
+	maxInterval     time.Duration        // 最大查询间隔，默认为 1 小时
+	stableRounds    int                  // 判定数据稳定所需的连续不变次数，默认为 3
+	minDuration     time.Duration        // 最短跟踪时长，默认为 1 小时
+	maxDuration     time.Duration        // 最长跟踪时长，默认为 24 小时
+	minRemaining    int                  // 为其他调用方预留的 API 剩余可用次数，默认为 5
+	receivedOnly    bool                 // 是否仅使用「送达统计详情」（非 VIP 用户），默认为 false
+	store           Store                // 持久化存储，为 nil 时不持久化
+	callback        func(result *Result) // 跟踪结束回调
+	results         chan<- *Result       // 跟踪结束结果通道
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 送达跟踪器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置送达跟踪器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 查询间隔配置选项。
+type intervalOption struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+func (o intervalOption) apply(c *config) error {
+	if o.initial <= 0 {
+		return errors.New("`initial` interval must be positive")
+	}
+	if o.max < o.initial {
+		return errors.New("`max` interval cannot be less than `initial` interval")
+	}
+	c.initialInterval = o.initial
+	c.maxInterval = o.max
+	return nil
+}
+
+// 自定义配置查询间隔：首次查询间隔为 `initial`，此后数据每次未发生变化时间隔翻倍，最大不超过 `max`。默认为 5 分钟和 1 小时。
+func WithInterval(initial, max time.Duration) ConfigOption {
+	return intervalOption{initial, max}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 数据稳定判定配置选项。
+type stableRoundsOption int
+
+func (o stableRoundsOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`rounds` must be positive")
+	}
+	c.stableRounds = int(o)
+	return nil
+}
+
+// 自定义配置判定数据稳定所需的连续不变次数，默认为 3。
+func WithStableRounds(rounds int) ConfigOption {
+	return stableRoundsOption(rounds)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 跟踪时长配置选项。
+type durationOption struct {
+	min time.Duration
+	max time.Duration
+}
+
+func (o durationOption) apply(c *config) error {
+	if o.min < 0 {
+		return errors.New("`min` duration cannot be negative")
+	}
+	if o.max <= 0 || o.max < o.min {
+		return errors.New("`max` duration must be positive and not less than `min` duration")
+	}
+	c.minDuration = o.min
+	c.maxDuration = o.max
+	return nil
+}
+
+// 自定义配置跟踪时长：跟踪至少持续 `min`，即使数据已稳定；最多持续 `max`，超出后即使数据仍在变化也会结束跟踪。默认为 1 小时和 24 小时。
+func WithDuration(min, max time.Duration) ConfigOption {
+	return durationOption{min, max}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// API 频率控制配置选项。
+type minRemainingOption int
+
+func (o minRemainingOption) apply(c *config) error {
+	if o < 0 {
+		return errors.New("`minRemaining` cannot be negative")
+	}
+	c.minRemaining = int(o)
+	return nil
+}
+
+// 自定义配置为其他调用方预留的统计 API 剩余可用次数，当响应头中的 X-Rate-Limit-Remaining 不大于该值时，
+// 跟踪器会暂停查询直到时间窗口重置。默认为 5。
+func WithMinRemaining(minRemaining int) ConfigOption {
+	return minRemainingOption(minRemaining)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 仅使用「送达统计详情」配置选项。
+type receivedOnlyOption bool
+
+func (o receivedOnlyOption) apply(c *config) error {
+	c.receivedOnly = bool(o)
+	return nil
+}
+
+// 配置仅使用「送达统计详情」GetReceivedDetail 查询数据（适用于非 VIP 用户），默认使用「消息统计详情（VIP）」GetMessageDetail。
+//
+// 注意：送达统计详情只包含发送和送达指标。
+func WithReceivedDetailOnly() ConfigOption {
+	return receivedOnlyOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 持久化存储配置选项。
+type storeOption struct {
+	store Store
+}
+
+func (o storeOption) apply(c *config) error {
+	if o.store == nil {
+		return errors.New("`store` cannot be nil")
+	}
+	c.store = o.store
+	return nil
+}
+
+// 配置持久化存储，跟踪中的 msg_id 及其状态会被保存下来，以便服务重启后继续跟踪。可使用 NewFileStore 或自定义实现。
+func WithStore(store Store) ConfigOption {
+	return storeOption{store}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 跟踪结束回调配置选项。
+type callbackOption func(result *Result)
+
+func (o callbackOption) apply(c *config) error {
+	if o == nil {
+		return errors.New("`callback` cannot be nil")
+	}
+	c.callback = o
+	return nil
+}
+
+// 配置跟踪结束回调，每个 msg_id 跟踪结束时都会以最终的推送漏斗调用一次。
+func WithCallback(callback func(result *Result)) ConfigOption {
+	return callbackOption(callback)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 跟踪结束结果通道配置选项。
+type resultChannelOption chan<- *Result
+
+func (o resultChannelOption) apply(c *config) error {
+	if o == nil {
+		return errors.New("`results` channel cannot be nil")
+	}
+	c.results = o
+	return nil
+}
+
+// 配置跟踪结束结果通道，每个 msg_id 跟踪结束时都会将最终结果发送到该通道（发送会阻塞跟踪器，请及时消费）。
+func WithResultChannel(results chan<- *Result) ConfigOption {
+	return resultChannelOption(results)
+}
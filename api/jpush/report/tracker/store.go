@@ -0,0 +1,173 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report/funnel"
+)
+
+// # 跟踪条目
+//
+// 跟踪器中某个 msg_id 的跟踪状态，用于持久化存储。
+type Entry struct {
+	MsgID        string         `json:"msg_id"`         // 推送消息 ID
+	RegisteredAt time.Time      `json:"registered_at"`  // 开始跟踪时间
+	NextPollAt   time.Time      `json:"next_poll_at"`   // 下次查询时间
+	Interval     time.Duration  `json:"interval"`       // 当前查询间隔
+	Polls        int            `json:"polls"`          // 已查询次数
+	StableRounds int            `json:"stable_rounds"`  // 数据连续不变的次数
+	Last         *funnel.Funnel `json:"last,omitempty"` // 最近一次查询到的推送漏斗
+}
+
+// # 持久化存储
+//
+// 用于保存跟踪中的条目，以便服务重启后继续跟踪。实现需要保证并发安全。
+type Store interface {
+	// 加载所有跟踪中的条目。
+	Load(ctx context.Context) ([]*Entry, error)
+	// 保存（新增或更新）跟踪条目。
+	Save(ctx context.Context, entry *Entry) error
+	// 删除跟踪条目。
+	Delete(ctx context.Context, msgID string) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 文件持久化存储
+//
+// 将所有跟踪条目以 JSON 格式保存在单个本地文件中，每次变更都会先写入临时文件再原子替换。适用于跟踪量不大的单实例场景。
+type FileStore struct {
+	path    string
+	mu      sync.Mutex
+	entries map[string]*Entry
+	loaded  bool
+}
+
+// 创建新的文件持久化存储实例，`path` 为保存跟踪条目的文件路径，文件不存在时会在首次保存时自动创建。
+func NewFileStore(path string) (*FileStore, error) {
+	if path == "" {
+		return nil, errors.New("`path` cannot be empty")
+	}
+	return &FileStore{path: path}, nil
+}
+
+func (s *FileStore) Load(_ context.Context) ([]*Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		cp := *e
+		entries = append(entries, &cp)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RegisteredAt.Before(entries[j].RegisteredAt)
+	})
+	return entries, nil
+}
+
+func (s *FileStore) Save(_ context.Context, entry *Entry) error {
+	if entry == nil {
+		return errors.New("`entry` cannot be nil")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	cp := *entry
+	s.entries[entry.MsgID] = &cp
+	return s.flush()
+}
+
+func (s *FileStore) Delete(_ context.Context, msgID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.load(); err != nil {
+		return err
+	}
+	if _, ok := s.entries[msgID]; !ok {
+		return nil
+	}
+	delete(s.entries, msgID)
+	return s.flush()
+}
+
+func (s *FileStore) load() error {
+	if s.loaded {
+		return nil
+	}
+	s.entries = make(map[string]*Entry)
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.loaded = true
+			return nil
+		}
+		return err
+	}
+	if len(data) > 0 {
+		var entries []*Entry
+		if err = json.Unmarshal(data, &entries); err != nil {
+			return err
+		}
+		for _, e := range entries {
+			s.entries[e.MsgID] = e
+		}
+	}
+	s.loaded = true
+	return nil
+}
+
+func (s *FileStore) flush() error {
+	entries := make([]*Entry, 0, len(s.entries))
+	for _, e := range s.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RegisteredAt.Before(entries[j].RegisteredAt)
+	})
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
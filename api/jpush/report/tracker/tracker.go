@@ -0,0 +1,484 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracker
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report/funnel"
+)
+
+// # 跟踪结束原因
+type FinishReason string
+
+const (
+	FinishStable  FinishReason = "stable"  // 数据已稳定
+	FinishTimeout FinishReason = "timeout" // 超出最长跟踪时长
+)
+
+// # 跟踪结果
+type Result struct {
+	MsgID        string         `json:"msg_id"`        // 推送消息 ID
+	Reason       FinishReason   `json:"reason"`        // 跟踪结束原因
+	Funnel       *funnel.Funnel `json:"funnel"`        // 最终的推送漏斗
+	Polls        int            `json:"polls"`         // 查询次数
+	RegisteredAt time.Time      `json:"registered_at"` // 开始跟踪时间
+	FinishedAt   time.Time      `json:"finished_at"`   // 结束跟踪时间
+}
+
+// # 送达跟踪器
+//
+// 推送成功后，将 msg_id 注册到跟踪器中，跟踪器会按退避策略定期调用统计 API 查询其统计数据（多个 msg_id 合并查询），
+// 并根据响应头中的频率控制信息避免耗尽统计 API 的调用配额。当数据连续多次不再变化（且已达到最短跟踪时长）或超出最长跟踪时长时，
+// 跟踪器会通过 WithCallback 和 WithResultChannel 配置的回调和通道输出最终的推送漏斗。
+//
+// 配置 WithStore 后，跟踪状态会被持久化，服务重启后可继续跟踪。
+type Tracker struct {
+	report  report.APIv3
+	cfg     config
+	mu      sync.Mutex
+	entries map[string]*Entry
+	loaded  bool
+	rate    api.Rate  // 最近一次响应的频率控制信息
+	rateAt  time.Time // 最近一次响应的时间
+	wake    chan struct{}
+	now     func() time.Time
+}
+
+// 创建新的送达跟踪器实例。
+func NewTracker(reportAPI report.APIv3, opts ...ConfigOption) (*Tracker, error) {
+	if reportAPI == nil {
+		return nil, api.ErrNilJPushReportAPIv3
+	}
+
+	c := config{
+		logger:          api.DefaultJPushLogger,
+		initialInterval: defaultInitialInterval,
+		maxInterval:     defaultMaxInterval,
+		stableRounds:    defaultStableRounds,
+		minDuration:     defaultMinDuration,
+		maxDuration:     defaultMaxDuration,
+		minRemaining:    defaultMinRemaining,
+	}
+
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Tracker{
+		report:  reportAPI,
+		cfg:     c,
+		entries: make(map[string]*Entry),
+		wake:    make(chan struct{}, 1),
+		now:     time.Now,
+	}, nil
+}
+
+// 注册需要跟踪的 msg_id，已在跟踪中的 msg_id 会被忽略。
+func (t *Tracker) Track(ctx context.Context, msgIDs ...string) error {
+	if len(msgIDs) == 0 {
+		return errors.New("`msgIDs` cannot be empty")
+	}
+	if err := t.ensureLoaded(ctx); err != nil {
+		return err
+	}
+
+	now := t.now()
+	var added []*Entry
+	t.mu.Lock()
+	for _, msgID := range msgIDs {
+		if msgID == "" {
+			t.mu.Unlock()
+			return errors.New("`msgID` cannot be empty")
+		}
+		if _, ok := t.entries[msgID]; ok {
+			continue
+		}
+		e := &Entry{
+			MsgID:        msgID,
+			RegisteredAt: now,
+			NextPollAt:   now.Add(t.cfg.initialInterval),
+			Interval:     t.cfg.initialInterval,
+		}
+		t.entries[msgID] = e
+		cp := *e
+		added = append(added, &cp)
+	}
+	t.mu.Unlock()
+
+	if t.cfg.store != nil {
+		for _, e := range added {
+			if err := t.cfg.store.Save(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	t.notify()
+	return nil
+}
+
+// 取消跟踪指定的 msg_id，不会输出跟踪结果。
+func (t *Tracker) Untrack(ctx context.Context, msgID string) error {
+	if err := t.ensureLoaded(ctx); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	delete(t.entries, msgID)
+	t.mu.Unlock()
+	if t.cfg.store != nil {
+		return t.cfg.store.Delete(ctx, msgID)
+	}
+	return nil
+}
+
+// 获取所有跟踪中的 msg_id，按开始跟踪时间排序。
+func (t *Tracker) Pending() []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	entries := make([]*Entry, 0, len(t.entries))
+	for _, e := range t.entries {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].RegisteredAt.Before(entries[j].RegisteredAt)
+	})
+	msgIDs := make([]string, len(entries))
+	for i, e := range entries {
+		msgIDs[i] = e.MsgID
+	}
+	return msgIDs
+}
+
+// # 运行跟踪器
+//
+// 阻塞运行，按计划查询所有跟踪中的 msg_id，直到 `ctx` 被取消。
+func (t *Tracker) Run(ctx context.Context) error {
+	if err := t.ensureLoaded(ctx); err != nil {
+		return err
+	}
+
+	for {
+		var timer <-chan time.Time
+		if wait, ok := t.nextWait(); ok {
+			tm := time.NewTimer(wait)
+			timer = tm.C
+			select {
+			case <-ctx.Done():
+				tm.Stop()
+				return ctx.Err()
+			case <-t.wake:
+				tm.Stop()
+				continue
+			case <-timer:
+			}
+		} else {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-t.wake:
+				continue
+			}
+		}
+
+		if err := t.Poll(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// # 执行一轮查询
+//
+// 查询所有已到查询时间的 msg_id。通常无需直接调用，由 Run 按计划调用；也可以在自己的定时任务中调用。
+// 统计 API 查询失败只会记录日志并推迟下次查询，仅在 `ctx` 被取消或持久化存储出错时返回错误。
+func (t *Tracker) Poll(ctx context.Context) error {
+	if err := t.ensureLoaded(ctx); err != nil {
+		return err
+	}
+
+	now := t.now()
+	due := t.due(now)
+	for start := 0; start < len(due); start += maxMsgIDsPerRequest {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if wait := t.quotaWait(now); wait > 0 {
+			t.cfg.logger.Infof(ctx, "统计 API 剩余可用次数不足，暂停查询 %s", wait)
+			return nil
+		}
+
+		end := start + maxMsgIDsPerRequest
+		if end > len(due) {
+			end = len(due)
+		}
+		chunk := due[start:end]
+
+		funnels, err := t.query(ctx, chunk)
+		if err != nil {
+			t.cfg.logger.Warnf(ctx, "查询 %d 个 msg_id 的统计数据失败：%s", len(chunk), err)
+			if err = t.backoff(ctx, chunk, now); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, msgID := range chunk {
+			if err = t.update(ctx, msgID, funnels[msgID], now); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (t *Tracker) ensureLoaded(ctx context.Context) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.loaded || t.cfg.store == nil {
+		t.loaded = true
+		return nil
+	}
+	entries, err := t.cfg.store.Load(ctx)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := t.entries[e.MsgID]; !ok {
+			t.entries[e.MsgID] = e
+		}
+	}
+	t.loaded = true
+	if len(entries) > 0 {
+		t.cfg.logger.Infof(ctx, "已从持久化存储中恢复 %d 个跟踪中的 msg_id", len(entries))
+	}
+	return nil
+}
+
+func (t *Tracker) notify() {
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// 计算距离下次查询的等待时长，无跟踪条目时返回 false。
+func (t *Tracker) nextWait() (time.Duration, bool) {
+	now := t.now()
+	t.mu.Lock()
+	var next time.Time
+	for _, e := range t.entries {
+		if next.IsZero() || e.NextPollAt.Before(next) {
+			next = e.NextPollAt
+		}
+	}
+	t.mu.Unlock()
+	if next.IsZero() {
+		return 0, false
+	}
+	wait := next.Sub(now)
+	if qw := t.quotaWait(now); qw > wait {
+		wait = qw
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// 获取已到查询时间的 msg_id，按下次查询时间排序。
+func (t *Tracker) due(now time.Time) []string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	var entries []*Entry
+	for _, e := range t.entries {
+		if !e.NextPollAt.After(now) {
+			entries = append(entries, e)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NextPollAt.Before(entries[j].NextPollAt)
+	})
+	msgIDs := make([]string, len(entries))
+	for i, e := range entries {
+		msgIDs[i] = e.MsgID
+	}
+	return msgIDs
+}
+
+// 根据最近一次响应的频率控制信息，计算需要等待的时长。
+func (t *Tracker) quotaWait(now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.rateAt.IsZero() || t.rate.Limit <= 0 || t.rate.Remaining > t.cfg.minRemaining {
+		return 0
+	}
+	resetAt := t.rateAt.Add(time.Duration(t.rate.Reset) * time.Second)
+	if !resetAt.After(now) {
+		return 0
+	}
+	return resetAt.Sub(now)
+}
+
+func (t *Tracker) query(ctx context.Context, msgIDs []string) (map[string]*funnel.Funnel, error) {
+	funnels := make(map[string]*funnel.Funnel, len(msgIDs))
+
+	var resp *api.Response
+	var codeErr *api.CodeError
+	if t.cfg.receivedOnly {
+		result, err := t.report.GetReceivedDetail(ctx, msgIDs)
+		if err != nil {
+			return nil, err
+		}
+		resp, codeErr = result.Response, result.Error
+		for i := range result.ReceivedDetails {
+			d := &result.ReceivedDetails[i]
+			funnels[d.MsgID] = funnel.FromReceivedDetail(d)
+		}
+	} else {
+		result, err := t.report.GetMessageDetail(ctx, msgIDs)
+		if err != nil {
+			return nil, err
+		}
+		resp, codeErr = result.Response, result.Error
+		for i := range result.MessageDetails {
+			d := &result.MessageDetails[i]
+			funnels[d.MsgID] = funnel.FromMessageDetail(d)
+		}
+	}
+
+	if resp != nil {
+		t.mu.Lock()
+		t.rate, t.rateAt = resp.Rate, t.now()
+		t.mu.Unlock()
+	}
+	if err := api.CheckResponse(resp, codeErr); err != nil {
+		return nil, err
+	}
+	return funnels, nil
+}
+
+// 查询失败时推迟下次查询。
+func (t *Tracker) backoff(ctx context.Context, msgIDs []string, now time.Time) error {
+	for _, msgID := range msgIDs {
+		t.mu.Lock()
+		e, ok := t.entries[msgID]
+		if !ok {
+			t.mu.Unlock()
+			continue
+		}
+		e.Interval = t.grow(e.Interval)
+		e.NextPollAt = now.Add(e.Interval)
+		cp := *e
+		t.mu.Unlock()
+		if t.cfg.store != nil {
+			if err := t.cfg.store.Save(ctx, &cp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 根据查询到的推送漏斗更新跟踪条目，并在跟踪结束时输出结果。
+func (t *Tracker) update(ctx context.Context, msgID string, f *funnel.Funnel, now time.Time) error {
+	t.mu.Lock()
+	e, ok := t.entries[msgID]
+	if !ok { // 已被取消跟踪
+		t.mu.Unlock()
+		return nil
+	}
+
+	e.Polls++
+	if f != nil && (e.Last == nil || f.Counts != e.Last.Counts) {
+		e.Last = f
+		e.StableRounds = 0
+	} else {
+		if e.Last != nil { // 尚无任何数据时不计入稳定次数
+			e.StableRounds++
+		}
+		e.Interval = t.grow(e.Interval)
+	}
+
+	var reason FinishReason
+	age := now.Sub(e.RegisteredAt)
+	switch {
+	case e.StableRounds >= t.cfg.stableRounds && age >= t.cfg.minDuration:
+		reason = FinishStable
+	case age >= t.cfg.maxDuration:
+		reason = FinishTimeout
+	}
+
+	if reason == "" {
+		e.NextPollAt = now.Add(e.Interval)
+		cp := *e
+		t.mu.Unlock()
+		if t.cfg.store != nil {
+			return t.cfg.store.Save(ctx, &cp)
+		}
+		return nil
+	}
+
+	delete(t.entries, msgID)
+	t.mu.Unlock()
+
+	result := &Result{
+		MsgID:        msgID,
+		Reason:       reason,
+		Funnel:       e.Last,
+		Polls:        e.Polls,
+		RegisteredAt: e.RegisteredAt,
+		FinishedAt:   now,
+	}
+	if result.Funnel == nil {
+		result.Funnel = &funnel.Funnel{MsgID: msgID}
+	}
+	if t.cfg.store != nil {
+		if err := t.cfg.store.Delete(ctx, msgID); err != nil {
+			return err
+		}
+	}
+	t.cfg.logger.Debugf(ctx, "msg_id %s 跟踪结束（%s），共查询 %d 次", msgID, reason, e.Polls)
+	return t.emit(ctx, result)
+}
+
+func (t *Tracker) emit(ctx context.Context, result *Result) error {
+	if t.cfg.callback != nil {
+		t.cfg.callback(result)
+	}
+	if t.cfg.results != nil {
+		select {
+		case t.cfg.results <- result:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) grow(interval time.Duration) time.Duration {
+	interval *= 2
+	if interval > t.cfg.maxInterval {
+		interval = t.cfg.maxInterval
+	}
+	return interval
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userstats
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const defaultConcurrency = 2 // 默认的并发查询数
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 用户统计范围查询器配置。
+type config struct {
+	logger      jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	concurrency int            // 并发查询数，默认为 2
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 用户统计范围查询器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置用户统计范围查询器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 并发查询数配置选项。
+type concurrencyOption int
+
+func (o concurrencyOption) apply(c *config) error {
+	if o <= 0 {
+		return errors.New("`concurrency` must be positive")
+	}
+	c.concurrency = int(o)
+	return nil
+}
+
+// 自定义配置同时进行的查询请求数，默认为 2。
+func WithConcurrency(n int) ConfigOption {
+	return concurrencyOption(n)
+}
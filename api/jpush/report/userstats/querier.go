@@ -0,0 +1,262 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package userstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/greport"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/report"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 用户统计接口，report.APIv3 与 greport.APIv3 均实现了该接口。
+type userDetailGetter interface {
+	GetUserDetail(ctx context.Context, start jiguang.UnitTime, duration int) (*report.UserDetailGetResult, error)
+}
+
+// 各时间单位单次查询的最大持续时长。
+type limits map[jiguang.TimeUnit]int
+
+var (
+	reportLimits  = limits{jiguang.TimeUnitHour: 24, jiguang.TimeUnitDay: 60, jiguang.TimeUnitMonth: 2}
+	greportLimits = limits{jiguang.TimeUnitHour: 24, jiguang.TimeUnitDay: 30, jiguang.TimeUnitMonth: 1}
+)
+
+// # 查询窗口
+type Window struct {
+	Start    jiguang.UnitTime // 起始时间
+	Duration int              // 持续时长
+	Err      error            // 查询失败的原因，查询成功时为 nil
+}
+
+func (w Window) String() string {
+	return fmt.Sprintf("%s+%d", w.Start.Format(), w.Duration)
+}
+
+// # 用户统计时间序列
+type Series struct {
+	TimeUnit jiguang.TimeUnit       // 时间粒度
+	From     time.Time              // 起始时间（含），已按时间粒度对齐
+	To       time.Time              // 结束时间（不含），已按时间粒度对齐
+	Items    []report.UserStatsItem // 按时间排序、去重后的连续统计数据项，每个时间粒度一项
+	Gaps     []time.Time            // 没有返回统计数据的时间点，对应的数据项仅填充了 Time 字段
+	Windows  []Window               // 实际执行的查询窗口
+}
+
+// 查询失败的窗口。
+func (s *Series) Failed() []Window {
+	if s == nil {
+		return nil
+	}
+	var failed []Window
+	for _, w := range s.Windows {
+		if w.Err != nil {
+			failed = append(failed, w)
+		}
+	}
+	return failed
+}
+
+// 是否所有窗口均查询成功。
+func (s *Series) IsSuccess() bool {
+	return s != nil && len(s.Failed()) == 0
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 用户统计范围查询器
+//
+// 突破「用户统计」接口单次查询时长的限制：将任意时间范围 [from, to) 按时间粒度拆分为若干个合法的查询窗口，
+// 并发查询后将各窗口的统计数据项合并、去重，并补齐缺失的时间点，得到一条连续的时间序列。
+//
+// 单次查询时长的限制：
+//   - 用户统计（report）：HOUR 最多 24 小时且不能跨天，DAY 最多 60 天，MONTH 最多 2 个月；
+//   - 分组用户统计（greport）：HOUR 最多 24 小时且不能跨天，DAY 最多 30 天，MONTH 最多 1 个月。
+//
+// 注意：拆分窗口不会突破接口对数据保留时长的限制（如用户统计仅提供近 2 个月内的数据），超出的部分将查询失败或体现为缺失的时间点。
+type Querier struct {
+	getter userDetailGetter
+	limits limits
+	cfg    config
+}
+
+// 创建新的用户统计范围查询器，使用 report.APIv3 的 GetUserDetail 查询。
+func NewQuerier(reportAPI report.APIv3, opts ...ConfigOption) (*Querier, error) {
+	if reportAPI == nil {
+		return nil, api.ErrNilJPushReportAPIv3
+	}
+	return newQuerier(reportAPI, reportLimits, opts)
+}
+
+// 创建新的分组用户统计范围查询器，使用 greport.APIv3 的 GetUserDetail 查询。
+func NewGroupQuerier(greportAPI greport.APIv3, opts ...ConfigOption) (*Querier, error) {
+	if greportAPI == nil {
+		return nil, api.ErrNilJPushGroupReportAPIv3
+	}
+	return newQuerier(greportAPI, greportLimits, opts)
+}
+
+func newQuerier(getter userDetailGetter, limits limits, opts []ConfigOption) (*Querier, error) {
+	c := config{logger: api.DefaultJPushLogger, concurrency: defaultConcurrency}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+	return &Querier{getter: getter, limits: limits, cfg: c}, nil
+}
+
+// 将时间范围 [from, to) 按时间粒度 `unit`（HOUR、DAY 或 MONTH）拆分为合法的查询窗口。
+//
+// from 向下、to 向上对齐到时间粒度（按 API 时区，见 jiguang.APILocation）。
+func (q *Querier) Windows(from, to time.Time, unit jiguang.TimeUnit) ([]Window, error) {
+	max, ok := q.limits[unit]
+	if !ok {
+		return nil, fmt.Errorf("invalid time unit %q, only support HOUR, DAY, MONTH", unit)
+	}
+	from, to = align(from, unit, false), align(to, unit, true)
+	if !from.Before(to) {
+		return nil, errors.New("`from` must be before `to`")
+	}
+
+	var windows []Window
+	for start := from; start.Before(to); {
+		end := step(start, unit, max)
+		if unit == jiguang.TimeUnitHour {
+			// 小时粒度只支持输出当天的统计结果，窗口不能跨天
+			if nextDay := align(start, jiguang.TimeUnitDay, false).AddDate(0, 0, 1); end.After(nextDay) {
+				end = nextDay
+			}
+		}
+		if end.After(to) {
+			end = to
+		}
+		windows = append(windows, Window{Start: jiguang.UnitTime{Time: start, TimeUnit: unit}, Duration: count(start, end, unit)})
+		start = end
+	}
+	return windows, nil
+}
+
+// 查询时间范围 [from, to) 内按时间粒度 `unit` 的用户统计数据，合并为连续的时间序列。
+//
+// 单个窗口查询失败不会中止其余窗口，失败的窗口可从返回结果的 Failed 中获取，其覆盖的时间点会出现在 Gaps 中。
+func (q *Querier) Query(ctx context.Context, from, to time.Time, unit jiguang.TimeUnit) (*Series, error) {
+	windows, err := q.Windows(from, to, unit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]report.UserStatsItem, len(windows))
+	api.RunConcurrently(len(windows), q.cfg.concurrency, func(i int) {
+		results[i], windows[i].Err = q.query(ctx, windows[i])
+		if windows[i].Err != nil {
+			q.cfg.logger.Errorf(ctx, "查询时间窗口 %s 的用户统计失败：%s", windows[i], windows[i].Err)
+		}
+	})
+
+	series := &Series{TimeUnit: unit, From: align(from, unit, false), To: align(to, unit, true), Windows: windows}
+	merged := make(map[string]report.UserStatsItem)
+	for _, items := range results {
+		for _, item := range items {
+			if item.Time.IsZero() {
+				continue
+			}
+			key := unit.Format(align(item.Time.Time, unit, false))
+			if existing, ok := merged[key]; !ok || richness(item) > richness(existing) {
+				merged[key] = item
+			}
+		}
+	}
+	for t := series.From; t.Before(series.To); t = step(t, unit, 1) {
+		if item, ok := merged[unit.Format(t)]; ok {
+			series.Items = append(series.Items, item)
+			continue
+		}
+		series.Items = append(series.Items, report.UserStatsItem{Time: jiguang.UnitTime{Time: t, TimeUnit: unit}})
+		series.Gaps = append(series.Gaps, t)
+	}
+	return series, nil
+}
+
+func (q *Querier) query(ctx context.Context, w Window) ([]report.UserStatsItem, error) {
+	result, err := q.getter.GetUserDetail(ctx, w.Start, w.Duration)
+	if err != nil {
+		return nil, err
+	}
+	if err = api.CheckResponse(result.Response, result.Error); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 按 API 时区将 `t` 对齐到时间粒度 `unit`，`up` 为 true 时向上对齐。
+func align(t time.Time, unit jiguang.TimeUnit, up bool) time.Time {
+	t = t.In(jiguang.APILocation())
+	var aligned time.Time
+	switch unit {
+	case jiguang.TimeUnitHour:
+		aligned = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+	case jiguang.TimeUnitDay:
+		aligned = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	default:
+		aligned = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+	}
+	if up && aligned.Before(t) {
+		aligned = step(aligned, unit, 1)
+	}
+	return aligned
+}
+
+func step(t time.Time, unit jiguang.TimeUnit, n int) time.Time {
+	switch unit {
+	case jiguang.TimeUnitHour:
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+n, 0, 0, 0, t.Location())
+	case jiguang.TimeUnitDay:
+		return t.AddDate(0, 0, n)
+	default:
+		return t.AddDate(0, n, 0)
+	}
+}
+
+func count(from, to time.Time, unit jiguang.TimeUnit) int {
+	n := 0
+	for t := from; t.Before(to); t = step(t, unit, 1) {
+		n++
+	}
+	return n
+}
+
+// 统计数据项中已填充的字段数，用于重复数据项的取舍。
+func richness(item report.UserStatsItem) int {
+	n := 0
+	for _, d := range []*report.UserStatsItemDetail{item.Android, item.IOS, item.QuickApp, item.HMOS} {
+		if d == nil {
+			continue
+		}
+		for _, v := range []*uint64{d.New, d.Online, d.Active} {
+			if v != nil {
+				n++
+			}
+		}
+	}
+	return n
+}
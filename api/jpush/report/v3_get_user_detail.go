@@ -58,6 +58,14 @@ func (r *apiv3) GetUserDetail(ctx context.Context, start jiguang.UnitTime, durat
 		}
 	}
 
+	if tu == jiguang.TimeUnitHour {
+		loc := r.loc
+		if loc == nil {
+			loc = jiguang.APILocation()
+		}
+		start.Time = start.In(loc)
+	}
+
 	query := "?time_unit=" + tu.String() + "&start=" + url.QueryEscape(start.Format()) + "&duration=" + strconv.Itoa(duration)
 	req := &api.Request{
 		Method: http.MethodGet,
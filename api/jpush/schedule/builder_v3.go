@@ -17,6 +17,7 @@ package schedule
 import (
 	"encoding/base64"
 	"errors"
+	"time"
 
 	"github.com/cavlabs/jiguang-sdk-go/api"
 	"github.com/cavlabs/jiguang-sdk-go/jiguang"
@@ -30,6 +31,9 @@ type APIv3Builder struct {
 	masterSecret string
 	logger       jiguang.Logger
 	httpLogLevel api.HttpLogLevel
+	sm2          bool
+	sm2Keys      jiguang.SM2KeyProvider
+	loc          *time.Location
 	err          error
 }
 
@@ -95,6 +99,40 @@ func (b *APIv3Builder) DisableHttpLogging() *APIv3Builder {
 	return b
 }
 
+// 【可选】启用 SM2 加密推送，默认不启用。
+//
+// 启用后，ScheduleSend、ScheduleTemplateSend 及 CustomScheduleSend 接口的请求正文都会使用 SM2 公钥加密，并携带请求头 `X-Encrypt-Type: SM2`。
+func (b *APIv3Builder) EnableSM2Encryption() *APIv3Builder {
+	b.sm2 = true
+	return b
+}
+
+// 【可选】设置 SM2 加密推送使用的公钥提供者，默认为 jiguang.DefaultSM2KeyProvider（极光推送服务端的 SM2 公钥）。
+//
+// 可通过 jiguang.NewSM2Key 解析 PEM、DER 或 Base64 编码的公钥点格式的公钥，并通过 jiguang.NewSM2KeyRing 组合多个同时有效的公钥，
+// 以便在公钥轮换期间平滑切换；公钥标识非空时，会通过请求头 `X-Encrypt-Key-Id` 告知服务端。
+func (b *APIv3Builder) SetSM2KeyProvider(keys jiguang.SM2KeyProvider) *APIv3Builder {
+	if keys == nil {
+		b.err = errors.New("`keys` cannot be nil")
+		return b
+	}
+	b.sm2Keys = keys
+	return b
+}
+
+// 【可选】设置 API 使用的时区，默认为 jiguang.APILocation()（Asia/Shanghai）。
+//
+// ScheduleSend、ScheduleTemplateSend 及 UpdateSchedule 在提交前，会将触发条件中的日期时间（单次触发时间、有效起止时间）换算到该时区；
+// 定期任务的执行时间（Periodical.Time）为墙上时间，不做换算。
+func (b *APIv3Builder) SetAPILocation(loc *time.Location) *APIv3Builder {
+	if loc == nil {
+		b.err = errors.New("`loc` cannot be nil")
+		return b
+	}
+	b.loc = loc
+	return b
+}
+
 func (b *APIv3Builder) Build() (APIv3, error) {
 	if b.err != nil {
 		return (*apiv3)(nil), b.err
@@ -108,17 +146,23 @@ func (b *APIv3Builder) Build() (APIv3, error) {
 	creds := base64.StdEncoding.EncodeToString([]byte(b.appKey + ":" + b.masterSecret))
 
 	return &apiv3{
-		client: client,
-		proto:  proto,
-		host:   b.host,
-		auth:   "Basic " + creds,
+		client:  client,
+		proto:   proto,
+		host:    b.host,
+		auth:    "Basic " + creds,
+		sm2:     b.sm2,
+		sm2Keys: b.sm2Keys,
+		loc:     b.loc,
 	}, nil
 }
 
 // apiv3 内部实现了 APIv3，是 Schedule API v3 的默认访问客户端。
 type apiv3 struct {
-	client api.HttpClient
-	proto  string
-	host   string
-	auth   string
+	client  api.HttpClient
+	proto   string
+	host    string
+	auth    string
+	sm2     bool                   // 是否启用 SM2 加密推送
+	sm2Keys jiguang.SM2KeyProvider // SM2 加密推送使用的公钥提供者，为 nil 时使用默认公钥
+	loc     *time.Location         // API 使用的时区，为 nil 时使用 jiguang.APILocation()
 }
@@ -0,0 +1,167 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// # 预览触发时间
+//
+// 在本地计算定时任务触发条件 `trigger` 在 `after` 之后（不含）的至多 `n` 次触发时间，按时间先后排序。
+//
+// 计算规则：
+//   - 触发条件在 API 时区（见 jiguang.APILocation）中计算，返回的触发时间转换到 `after` 的时区；
+//   - 定期任务的执行周期从 StartTime 所在的天 / 周（周一为一周的第一天）/ 月开始计算，每隔 Frequency 个周期执行一次；
+//   - 月执行点在当月没有该日期时（如 2 月 30 日），当月跳过；
+//   - 触发时间需落在 [StartTime, EndTime] 范围内。
+func Preview(trigger *schedule.Trigger, after time.Time, n int) ([]time.Time, error) {
+	if trigger == nil {
+		return nil, errors.New("`trigger` cannot be nil")
+	}
+	if n <= 0 {
+		return nil, nil
+	}
+	loc := jiguang.APILocation()
+
+	if trigger.Single != nil {
+		at := trigger.Single.Time.InAPILocation()
+		if at.After(after) {
+			return []time.Time{at.In(after.Location())}, nil
+		}
+		return nil, nil
+	}
+
+	p := trigger.Periodical
+	if p == nil {
+		return nil, errors.New("trigger has neither single nor periodical")
+	}
+	if p.Frequency < 1 {
+		return nil, fmt.Errorf("invalid frequency %d", p.Frequency)
+	}
+	weekdays, monthdays, err := parsePoints(p.TimeUnit, p.Point)
+	if err != nil {
+		return nil, err
+	}
+
+	start, end := p.StartTime.InAPILocation(), p.EndTime.InAPILocation()
+	startDay := truncateDay(start)
+	hour, min, sec := p.Time.InAPILocation().Clock()
+
+	var fires []time.Time
+	for day := truncateDay(maxTime(start, after.In(loc))); !day.After(end) && len(fires) < n; day = day.AddDate(0, 0, 1) {
+		if !matches(p.TimeUnit, p.Frequency, startDay, day, weekdays, monthdays) {
+			continue
+		}
+		at := time.Date(day.Year(), day.Month(), day.Day(), hour, min, sec, 0, loc)
+		if at.After(after) && !at.Before(start) && !at.After(end) {
+			fires = append(fires, at.In(after.Location()))
+		}
+	}
+	return fires, nil
+}
+
+// 预览触发规则 `spec` 在 `after` 之后的至多 `n` 次触发时间，start、end 同 Spec.Trigger。
+func PreviewSpec(spec string, start, end, after time.Time, n int) ([]time.Time, error) {
+	trigger, err := ToTrigger(spec, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return Preview(trigger, after, n)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func matches(unit jiguang.TimeUnit, frequency int, startDay, day time.Time, weekdays map[time.Weekday]bool, monthdays map[int]bool) bool {
+	switch unit {
+	case jiguang.TimeUnitDay:
+		return daysBetween(startDay, day)%frequency == 0
+	case jiguang.TimeUnitWeek:
+		if len(weekdays) > 0 && !weekdays[day.Weekday()] {
+			return false
+		}
+		if len(weekdays) == 0 && day.Weekday() != startDay.Weekday() {
+			return false
+		}
+		return daysBetween(weekStart(startDay), weekStart(day))/7%frequency == 0
+	case jiguang.TimeUnitMonth:
+		if len(monthdays) > 0 && !monthdays[day.Day()] {
+			return false
+		}
+		if len(monthdays) == 0 && day.Day() != startDay.Day() {
+			return false
+		}
+		months := (day.Year()-startDay.Year())*12 + int(day.Month()) - int(startDay.Month())
+		return months%frequency == 0
+	}
+	return false
+}
+
+func parsePoints(unit jiguang.TimeUnit, points []string) (map[time.Weekday]bool, map[int]bool, error) {
+	switch unit {
+	case jiguang.TimeUnitDay:
+		return nil, nil, nil
+	case jiguang.TimeUnitWeek:
+		weekdays := make(map[time.Weekday]bool, len(points))
+		for _, p := range points {
+			d, ok := weekdayNames[strings.ToLower(p)]
+			if !ok || len(p) != 3 {
+				return nil, nil, fmt.Errorf("invalid week point %q", p)
+			}
+			weekdays[time.Weekday(d)] = true
+		}
+		return weekdays, nil, nil
+	case jiguang.TimeUnitMonth:
+		monthdays := make(map[int]bool, len(points))
+		for _, p := range points {
+			d, err := strconv.Atoi(p)
+			if err != nil || d < 1 || d > 31 {
+				return nil, nil, fmt.Errorf("invalid month point %q", p)
+			}
+			monthdays[d] = true
+		}
+		return nil, monthdays, nil
+	}
+	return nil, nil, fmt.Errorf("%w: time unit %q", ErrUnsupported, unit)
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func weekStart(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+func daysBetween(from, to time.Time) int {
+	// 使用 UTC 日期计算，避免夏令时切换造成的误差
+	f := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	t := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(t.Sub(f).Hours() / 24)
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
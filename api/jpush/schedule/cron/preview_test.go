@@ -0,0 +1,129 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule/cron"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// API 时区中的时间。
+func at(year int, month time.Month, day, hour, min int) time.Time {
+	return time.Date(year, month, day, hour, min, 0, 0, jiguang.APILocation())
+}
+
+func TestPreviewSpec(t *testing.T) {
+	start, end := at(2025, 1, 1, 0, 0), at(2025, 4, 30, 23, 59) // 2025-01-01 为周三
+
+	tests := []struct {
+		name  string
+		spec  string
+		after time.Time
+		n     int
+		want  []time.Time
+	}{
+		{"every 2 days", "every 2 days at 09:30", start, 3,
+			[]time.Time{at(2025, 1, 1, 9, 30), at(2025, 1, 3, 9, 30), at(2025, 1, 5, 9, 30)}},
+		{"after excludes same time", "daily at 09:30", at(2025, 1, 1, 9, 30), 2,
+			[]time.Time{at(2025, 1, 2, 9, 30), at(2025, 1, 3, 9, 30)}},
+		{"weekly points", "every Mon,Wed at 09:30", start, 3,
+			[]time.Time{at(2025, 1, 1, 9, 30), at(2025, 1, 6, 9, 30), at(2025, 1, 8, 9, 30)}},
+		{"every 2 weeks from start week", "every 2 weeks on Mon at 09:30", start, 2,
+			[]time.Time{at(2025, 1, 13, 9, 30), at(2025, 1, 27, 9, 30)}},
+		{"month skips missing day", "monthly on 31 at 08:00", start, 3,
+			[]time.Time{at(2025, 1, 31, 8, 0), at(2025, 3, 31, 8, 0)}},
+		{"every 3 months", "every 3 months on 15 at 08:00", start, 3,
+			[]time.Time{at(2025, 1, 15, 8, 0), at(2025, 4, 15, 8, 0)}},
+		{"bounded by end", "daily at 09:00", at(2025, 4, 29, 12, 0), 5,
+			[]time.Time{at(2025, 4, 30, 9, 0)}},
+		{"after end", "daily at 09:00", at(2025, 5, 1, 0, 0), 5, nil},
+		{"zero n", "daily at 09:00", start, 0, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cron.PreviewSpec(tt.spec, start, end, tt.after, tt.n)
+			if err != nil {
+				t.Fatalf("PreviewSpec: %v", err)
+			}
+			assertTimes(t, got, tt.want)
+		})
+	}
+}
+
+func TestPreviewSingle(t *testing.T) {
+	fire := at(2024, 5, 1, 9, 30)
+	trigger, err := cron.ToTrigger("once at 2024-05-01 09:30", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	utc := fire.Add(-time.Hour).UTC()
+	got, err := cron.Preview(trigger, utc, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertTimes(t, got, []time.Time{fire})
+	if len(got) == 1 && got[0].Location() != time.UTC {
+		t.Errorf("location = %s, want the location of `after`", got[0].Location())
+	}
+
+	if got, _ = cron.Preview(trigger, fire, 3); len(got) != 0 {
+		t.Errorf("Preview after the fire time = %v, want none", got)
+	}
+}
+
+func TestPreviewInvalid(t *testing.T) {
+	p := &schedule.Periodical{
+		StartTime: jiguang.NewLocalDateTime(at(2025, 1, 1, 0, 0), nil),
+		EndTime:   jiguang.NewLocalDateTime(at(2025, 2, 1, 0, 0), nil),
+		Time:      jiguang.BuildLocalTime(9, 0, 0),
+		TimeUnit:  jiguang.TimeUnitWeek,
+		Frequency: 1,
+		Point:     []string{"MONDAY"},
+	}
+	tests := []struct {
+		name    string
+		trigger *schedule.Trigger
+	}{
+		{"nil trigger", nil},
+		{"empty trigger", &schedule.Trigger{}},
+		{"invalid point", &schedule.Trigger{Periodical: p}},
+	}
+	for _, tt := range tests {
+		if _, err := cron.Preview(tt.trigger, at(2025, 1, 1, 0, 0), 1); err == nil {
+			t.Errorf("%s: Preview should fail", tt.name)
+		}
+	}
+
+	if _, err := cron.ToTrigger("daily at 09:00", at(2025, 2, 1, 0, 0), at(2025, 1, 1, 0, 0)); err == nil {
+		t.Error("ToTrigger with end before start should fail")
+	}
+}
+
+func assertTimes(t *testing.T, got, want []time.Time) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("#%d = %s, want %s", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,454 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+const maxFrequency = 100 // 定期任务执行频次支持的最大值
+
+var (
+	ErrUnsupported = errors.New("spec cannot be expressed as a JPush schedule trigger") // 定时任务触发条件无法表达该规则
+	ErrEmptySpec   = errors.New("empty spec")                                           // 规则为空
+)
+
+// # 触发规则
+//
+// 由 Parse 从 cron 表达式或易读规则解析得到，通过 Trigger 方法转换为定时任务的触发条件。
+type Spec struct {
+	At        time.Time        // 单次触发的时间（按 API 时区解释），仅「once at ...」规则有效，此时其他字段均无效
+	TimeUnit  jiguang.TimeUnit // 定期任务的最小时间单位：jiguang.TimeUnitDay、jiguang.TimeUnitWeek 或 jiguang.TimeUnitMonth
+	Frequency int              // 定期任务的执行频次，[1, 100]
+	Point     []string         // 定期任务的执行点：周为 [MON, ..., SUN]，月为 [01, ..., 31]，天为空
+	Hour      int              // 定期任务每次执行的时（API 时区）
+	Minute    int              // 定期任务每次执行的分
+	Second    int              // 定期任务每次执行的秒
+}
+
+// 是否为单次触发规则。
+func (s *Spec) IsSingle() bool {
+	return s != nil && !s.At.IsZero()
+}
+
+// 将触发规则转换为定时任务的触发条件。
+//   - start、end：定期任务的有效起止时间（提交时转换到 API 时区），单次触发规则忽略这两个参数。
+func (s *Spec) Trigger(start, end time.Time) (*schedule.Trigger, error) {
+	if s == nil {
+		return nil, ErrEmptySpec
+	}
+	if s.IsSingle() {
+		return &schedule.Trigger{Single: &schedule.Single{Time: jiguang.NewLocalDateTime(s.At, nil)}}, nil
+	}
+
+	if start.IsZero() || end.IsZero() {
+		return nil, errors.New("periodical trigger requires both start and end time")
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("end time %s must be after start time %s", formatWall(end), formatWall(start))
+	}
+	if s.Frequency < 1 || s.Frequency > maxFrequency {
+		return nil, fmt.Errorf("%w: frequency %d out of range [1, %d]", ErrUnsupported, s.Frequency, maxFrequency)
+	}
+	switch s.TimeUnit {
+	case jiguang.TimeUnitDay, jiguang.TimeUnitWeek, jiguang.TimeUnitMonth:
+	default:
+		return nil, fmt.Errorf("%w: time unit %q", ErrUnsupported, s.TimeUnit)
+	}
+
+	p := &schedule.Periodical{
+		StartTime: jiguang.NewLocalDateTime(start, nil),
+		EndTime:   jiguang.NewLocalDateTime(end, nil),
+		Time:      jiguang.BuildLocalTime(s.Hour, s.Minute, s.Second),
+		TimeUnit:  s.TimeUnit,
+		Frequency: s.Frequency,
+	}
+	if s.TimeUnit != jiguang.TimeUnitDay {
+		p.Point = append([]string(nil), s.Point...)
+	}
+	return &schedule.Trigger{Periodical: p}, nil
+}
+
+func (s *Spec) String() string {
+	if s == nil {
+		return ""
+	}
+	if s.IsSingle() {
+		return "once at " + formatWall(s.At)
+	}
+	at := fmt.Sprintf("%02d:%02d:%02d", s.Hour, s.Minute, s.Second)
+	if s.TimeUnit == jiguang.TimeUnitDay {
+		return fmt.Sprintf("every %d %s at %s", s.Frequency, s.TimeUnit, at)
+	}
+	return fmt.Sprintf("every %d %s on %s at %s", s.Frequency, s.TimeUnit, strings.Join(s.Point, ","), at)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 将 cron 表达式或易读规则直接转换为定时任务的触发条件，等价于 Parse 后调用 Spec.Trigger。
+func ToTrigger(spec string, start, end time.Time) (*schedule.Trigger, error) {
+	s, err := Parse(spec)
+	if err != nil {
+		return nil, err
+	}
+	return s.Trigger(start, end)
+}
+
+// # 解析触发规则
+//
+// 支持以下两种形式：
+//
+// 1. 标准 cron 表达式（5 段「分 时 日 月 周」或 6 段「秒 分 时 日 月 周」），以及 @daily、@midnight、@weekly、@monthly；
+//   - 秒、分、时只能是单个值（JPush 定期任务每个执行日仅触发一次）；
+//   - 月只能是 "*"（或 "?"）；
+//   - 日与周不能同时限定（cron 中两者为「或」的关系，无法表达）；
+//   - 日、周支持列表（1,15）、范围（1-5）、步长（*/2）及英文缩写（MON-FRI）。
+//
+// 2. 易读规则（不区分大小写）：
+//   - "daily at 09:30"、"every day at 09:30"、"every 3 days at 09:30"；
+//   - "every Mon,Wed at 09:30"、"every weekday at 09:30"、"every weekend at 10:00"；
+//   - "weekly on Mon-Fri at 09:30"、"every 2 weeks on Mon,Thu at 09:30"；
+//   - "monthly on 1,15 at 09:30"、"every 3 months on 1 at 08:00"；
+//   - "once at 2024-05-01 09:30"（单次触发）。
+//
+// 无法用 JPush 定时任务触发条件表达的规则将返回包装了 ErrUnsupported 的错误。
+func Parse(spec string) (*Spec, error) {
+	spec = strings.Join(strings.Fields(spec), " ")
+	if spec == "" {
+		return nil, ErrEmptySpec
+	}
+	if isCron(spec) {
+		return parseCron(spec)
+	}
+	return parseHuman(strings.ToLower(spec))
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+var weekdayNames = map[string]int{
+	"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	"sunday": 0, "monday": 1, "tuesday": 2, "wednesday": 3, "thursday": 4, "friday": 5, "saturday": 6,
+}
+
+var monthNames = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+var weekPoints = [...]string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+var cronDescriptors = map[string]string{
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+func isCron(spec string) bool {
+	if strings.HasPrefix(spec, "@") {
+		return true
+	}
+	n := len(strings.Fields(spec))
+	return (n == 5 || n == 6) && !strings.ContainsAny(spec, ":") && !strings.Contains(strings.ToLower(spec), "every")
+}
+
+func parseCron(spec string) (*Spec, error) {
+	if strings.HasPrefix(spec, "@") {
+		expanded, ok := cronDescriptors[strings.ToLower(spec)]
+		if !ok {
+			return nil, fmt.Errorf("%w: descriptor %q", ErrUnsupported, spec)
+		}
+		spec = expanded
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) == 5 {
+		fields = append([]string{"0"}, fields...)
+	}
+
+	var (
+		values [6][]int
+		stars  [6]bool
+		err    error
+	)
+	bounds := [6]struct {
+		min, max int
+		names    map[string]int
+	}{{0, 59, nil}, {0, 59, nil}, {0, 23, nil}, {1, 31, nil}, {1, 12, monthNames}, {0, 7, weekdayNames}}
+	for i, f := range fields {
+		if values[i], stars[i], err = parseCronField(f, bounds[i].min, bounds[i].max, bounds[i].names); err != nil {
+			return nil, fmt.Errorf("invalid cron field %q: %w", f, err)
+		}
+	}
+
+	for i, name := range [...]string{"second", "minute", "hour"} {
+		if len(values[i]) != 1 {
+			return nil, fmt.Errorf("%w: %s must be a single value, a schedule fires at most once per day", ErrUnsupported, name)
+		}
+	}
+	if !stars[4] && len(values[4]) != 12 {
+		return nil, fmt.Errorf("%w: month must be \"*\"", ErrUnsupported)
+	}
+
+	s := &Spec{Frequency: 1, Hour: values[2][0], Minute: values[1][0], Second: values[0][0]}
+	dow := normalizeWeekdays(values[5])
+	domAll, dowAll := stars[3] || len(values[3]) == 31, stars[5] || len(dow) == 7
+	switch {
+	case domAll && dowAll:
+		s.TimeUnit = jiguang.TimeUnitDay
+	case domAll:
+		s.TimeUnit, s.Point = jiguang.TimeUnitWeek, weekdayPoints(dow)
+	case dowAll:
+		s.TimeUnit, s.Point = jiguang.TimeUnitMonth, monthdayPoints(values[3])
+	default:
+		return nil, fmt.Errorf("%w: day-of-month and day-of-week cannot both be restricted", ErrUnsupported)
+	}
+	return s, nil
+}
+
+// 解析单个 cron 字段，返回排序去重后的取值列表，以及是否为 "*"（"?"）。
+func parseCronField(field string, min, max int, names map[string]int) ([]int, bool, error) {
+	if field == "*" || field == "?" {
+		return rangeValues(min, max, 1), true, nil
+	}
+
+	set := make(map[int]struct{})
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n < 1 {
+				return nil, false, fmt.Errorf("invalid step %q", part[i+1:])
+			}
+			step, part = n, part[:i]
+		}
+
+		lo, hi := min, max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			if lo, err = parseCronValue(bounds[0], names); err != nil {
+				return nil, false, err
+			}
+			if hi, err = parseCronValue(bounds[1], names); err != nil {
+				return nil, false, err
+			}
+		default:
+			v, err := parseCronValue(part, names)
+			if err != nil {
+				return nil, false, err
+			}
+			lo = v
+			if step == 1 {
+				hi = v
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return nil, false, fmt.Errorf("value out of range [%d, %d]", min, max)
+		}
+		for _, v := range rangeValues(lo, hi, step) {
+			set[v] = struct{}{}
+		}
+	}
+	return sortedKeys(set), false, nil
+}
+
+func parseCronValue(s string, names map[string]int) (int, error) {
+	if v, ok := names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	return v, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+var (
+	onceRegexp  = regexp.MustCompile(`^once at (\d{4}-\d{2}-\d{2}) (\d{1,2}:\d{2}(?::\d{2})?)$`)
+	humanRegexp = regexp.MustCompile(`^(daily|weekly|monthly|every(?: (\d+))? ([a-z0-9,\- ]+?))(?: on ([a-z0-9,\- ]+?))? at (\d{1,2}:\d{2}(?::\d{2})?)$`)
+)
+
+func parseHuman(spec string) (*Spec, error) {
+	if m := onceRegexp.FindStringSubmatch(spec); m != nil {
+		h, min, sec, err := parseClock(m[2])
+		if err != nil {
+			return nil, err
+		}
+		d, err := time.Parse("2006-01-02", m[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid date %q: %w", m[1], err)
+		}
+		return &Spec{At: time.Date(d.Year(), d.Month(), d.Day(), h, min, sec, 0, jiguang.APILocation())}, nil
+	}
+
+	m := humanRegexp.FindStringSubmatch(spec)
+	if m == nil {
+		return nil, fmt.Errorf("unrecognized spec %q", spec)
+	}
+
+	s := &Spec{Frequency: 1}
+	var err error
+	if s.Hour, s.Minute, s.Second, err = parseClock(m[5]); err != nil {
+		return nil, err
+	}
+	if m[2] != "" {
+		if s.Frequency, err = strconv.Atoi(m[2]); err != nil {
+			return nil, fmt.Errorf("invalid frequency %q", m[2])
+		}
+	}
+
+	unit, on := m[1], m[4]
+	if strings.HasPrefix(unit, "every") {
+		unit = m[3]
+	}
+	switch unit {
+	case "daily", "day", "days":
+		if on != "" {
+			return nil, fmt.Errorf("%w: daily spec cannot have points", ErrUnsupported)
+		}
+		s.TimeUnit = jiguang.TimeUnitDay
+	case "weekly", "week", "weeks":
+		if on == "" {
+			return nil, errors.New("weekly spec requires \"on <weekdays>\"")
+		}
+		days, err := parseHumanList(on, 0, 6, weekdayNames)
+		if err != nil {
+			return nil, err
+		}
+		s.TimeUnit, s.Point = jiguang.TimeUnitWeek, weekdayPoints(days)
+	case "monthly", "month", "months":
+		if on == "" {
+			return nil, errors.New("monthly spec requires \"on <days>\"")
+		}
+		days, err := parseHumanList(on, 1, 31, nil)
+		if err != nil {
+			return nil, err
+		}
+		s.TimeUnit, s.Point = jiguang.TimeUnitMonth, monthdayPoints(days)
+	default:
+		if on != "" || m[2] != "" {
+			return nil, fmt.Errorf("unrecognized spec %q", spec)
+		}
+		var days []int
+		switch unit {
+		case "weekday", "weekdays":
+			days = []int{1, 2, 3, 4, 5}
+		case "weekend", "weekends":
+			days = []int{0, 6}
+		default:
+			if days, err = parseHumanList(unit, 0, 6, weekdayNames); err != nil {
+				return nil, err
+			}
+		}
+		s.TimeUnit, s.Point = jiguang.TimeUnitWeek, weekdayPoints(days)
+	}
+
+	if s.Frequency < 1 || s.Frequency > maxFrequency {
+		return nil, fmt.Errorf("%w: frequency %d out of range [1, %d]", ErrUnsupported, s.Frequency, maxFrequency)
+	}
+	if s.TimeUnit == jiguang.TimeUnitWeek && len(s.Point) == 7 && s.Frequency == 1 {
+		s.TimeUnit, s.Point = jiguang.TimeUnitDay, nil
+	}
+	return s, nil
+}
+
+// 解析易读规则中的列表，如 "mon,wed"、"mon-fri"、"1, 15"、"1-5"。
+func parseHumanList(list string, min, max int, names map[string]int) ([]int, error) {
+	list = strings.Replace(strings.Replace(list, " and ", ",", -1), " ", "", -1)
+	values, _, err := parseCronField(list, min, max, names)
+	if err != nil {
+		return nil, fmt.Errorf("invalid list %q: %w", list, err)
+	}
+	return values, nil
+}
+
+func parseClock(s string) (hour, min, sec int, err error) {
+	parts := strings.Split(s, ":")
+	vals := make([]int, 3)
+	for i, p := range parts {
+		if vals[i], err = strconv.Atoi(p); err != nil {
+			return 0, 0, 0, fmt.Errorf("invalid time %q", s)
+		}
+	}
+	hour, min, sec = vals[0], vals[1], vals[2]
+	if hour > 23 || min > 59 || sec > 59 {
+		return 0, 0, 0, fmt.Errorf("invalid time %q", s)
+	}
+	return
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func normalizeWeekdays(days []int) []int {
+	set := make(map[int]struct{}, len(days))
+	for _, d := range days {
+		set[d%7] = struct{}{}
+	}
+	return sortedKeys(set)
+}
+
+// 转换为周执行点，按 MON ... SUN 排序。
+func weekdayPoints(days []int) []string {
+	days = normalizeWeekdays(days)
+	sort.Slice(days, func(i, j int) bool { return (days[i]+6)%7 < (days[j]+6)%7 })
+	points := make([]string, len(days))
+	for i, d := range days {
+		points[i] = weekPoints[d]
+	}
+	return points
+}
+
+func monthdayPoints(days []int) []string {
+	points := make([]string, len(days))
+	for i, d := range days {
+		points[i] = fmt.Sprintf("%02d", d)
+	}
+	return points
+}
+
+func rangeValues(lo, hi, step int) []int {
+	var values []int
+	for v := lo; v <= hi; v += step {
+		values = append(values, v)
+	}
+	return values
+}
+
+func sortedKeys(set map[int]struct{}) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+func formatWall(t time.Time) string {
+	return t.Format("2006-01-02 15:04:05")
+}
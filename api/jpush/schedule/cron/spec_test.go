@@ -0,0 +1,87 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cron_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule/cron"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec    string
+		want    string
+		wantErr error
+	}{
+		// cron 表达式
+		{"30 9 * * *", "every 1 DAY at 09:30:00", nil},
+		{"30 9 * * 1-5", "every 1 WEEK on MON,TUE,WED,THU,FRI at 09:30:00", nil},
+		{"0 0 8 * * SUN,sat", "every 1 WEEK on SAT,SUN at 08:00:00", nil},
+		{"15 30 9 1,15 * ?", "every 1 MONTH on 01,15 at 09:30:15", nil},
+		{"0 9 */10 * *", "every 1 MONTH on 01,11,21,31 at 09:00:00", nil},
+		{"0 9 * * 0-6", "every 1 DAY at 09:00:00", nil},
+		{"@daily", "every 1 DAY at 00:00:00", nil},
+		{"@weekly", "every 1 WEEK on SUN at 00:00:00", nil},
+		{"@monthly", "every 1 MONTH on 01 at 00:00:00", nil},
+		{"*/5 9 * * *", "", cron.ErrUnsupported},
+		{"0 9-18 * * *", "", cron.ErrUnsupported},
+		{"0 9 1 * 1", "", cron.ErrUnsupported},
+		{"0 9 * 1 *", "", cron.ErrUnsupported},
+		{"@hourly", "", cron.ErrUnsupported},
+
+		// 易读规则
+		{"daily at 09:30", "every 1 DAY at 09:30:00", nil},
+		{"every 3 days at 8:05:09", "every 3 DAY at 08:05:09", nil},
+		{"Every Mon,Wed at 09:30", "every 1 WEEK on MON,WED at 09:30:00", nil},
+		{"every weekday at 09:30", "every 1 WEEK on MON,TUE,WED,THU,FRI at 09:30:00", nil},
+		{"every weekend at 10:00", "every 1 WEEK on SAT,SUN at 10:00:00", nil},
+		{"every 2 weeks on Mon and Thu at 09:30", "every 2 WEEK on MON,THU at 09:30:00", nil},
+		{"weekly on sun-sat at 09:30", "every 1 DAY at 09:30:00", nil},
+		{"monthly on 1, 15 at 09:30", "every 1 MONTH on 01,15 at 09:30:00", nil},
+		{"every 3 months on 1 at 08:00", "every 3 MONTH on 01 at 08:00:00", nil},
+		{"once at 2024-05-01 09:30", "once at 2024-05-01 09:30:00", nil},
+		{"every 101 days at 09:00", "", cron.ErrUnsupported},
+		{"daily on 1 at 09:00", "", cron.ErrUnsupported},
+
+		{"  ", "", cron.ErrEmptySpec},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			s, err := cron.Parse(tt.spec)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tt.spec, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.spec, err)
+			}
+			if got := s.String(); got != tt.want {
+				t.Errorf("Parse(%q) = %q, want %q", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, spec := range []string{"sometimes", "every day at 25:00", "0 60 9 * * *", "0 9 32 * *", "every 2 weekdays at 09:00", "weekly at 09:00"} {
+		if _, err := cron.Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should fail", spec)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"errors"
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+// 定时任务的匹配键函数，根据任务名称计算用于匹配期望与实际定时任务的键。
+type KeyFunc func(name string) string
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 定时任务调和器配置。
+type config struct {
+	logger     jiguang.Logger // 日志打印器，默认为 api.DefaultJPushLogger
+	keyFunc    KeyFunc        // 匹配键函数，默认直接使用任务名称
+	namePrefix string         // 受管定时任务的名称前缀，默认为空，表示所有定时任务均受管
+	prune      bool           // 是否删除未声明的受管定时任务，默认为 false
+	dryRun     bool           // 是否仅打印执行计划而不实际执行，默认为 false
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 定时任务调和器配置选项。
+type ConfigOption interface {
+	apply(*config) error
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 日志打印器配置选项。
+type loggerOption struct {
+	logger jiguang.Logger
+}
+
+func (o loggerOption) apply(c *config) error {
+	if o.logger == nil {
+		return errors.New("`logger` cannot be nil")
+	}
+	c.logger = o.logger
+	return nil
+}
+
+// 自定义配置定时任务调和器的日志打印器，默认为 api.DefaultJPushLogger。
+func WithLogger(logger jiguang.Logger) ConfigOption {
+	return loggerOption{logger}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 匹配键函数配置选项。
+type keyFuncOption struct {
+	keyFunc KeyFunc
+}
+
+func (o keyFuncOption) apply(c *config) error {
+	if o.keyFunc == nil {
+		return errors.New("`keyFunc` cannot be nil")
+	}
+	c.keyFunc = o.keyFunc
+	return nil
+}
+
+// 自定义配置匹配键函数，默认直接使用任务名称匹配。
+//
+// 例如任务名称形如 "<标签>_v<版本>" 时，可以只取标签部分作为匹配键，这样修改版本号将产生一次更新（含改名）而非删除后重建。
+func WithKeyFunc(keyFunc KeyFunc) ConfigOption {
+	return keyFuncOption{keyFunc}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 受管名称前缀配置选项。
+type namePrefixOption string
+
+func (o namePrefixOption) apply(c *config) error {
+	c.namePrefix = string(o)
+	return nil
+}
+
+// 自定义配置受管定时任务的名称前缀：名称不以该前缀开头的实际定时任务将被忽略，期望的定时任务名称也必须以该前缀开头。
+//
+// 默认为空，表示所有定时任务均受管。
+func WithNamePrefix(prefix string) ConfigOption {
+	return namePrefixOption(prefix)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 删除未声明定时任务配置选项。
+type pruneOption bool
+
+func (o pruneOption) apply(c *config) error {
+	c.prune = bool(o)
+	return nil
+}
+
+// 配置在执行计划中删除未声明的受管定时任务，默认不删除（仅在执行计划中标记为漂移）。
+//
+// 必须与非空的 WithNamePrefix 一起使用，否则 NewReconciler 返回错误，避免误删在极光控制台或其他系统中维护的定时任务。
+func WithPrune() ConfigOption {
+	return pruneOption(true)
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 试运行配置选项。
+type dryRunOption bool
+
+func (o dryRunOption) apply(c *config) error {
+	c.dryRun = bool(o)
+	return nil
+}
+
+// 配置为试运行：Apply 仅打印执行计划，不实际创建、更新或删除定时任务。
+func WithDryRun() ConfigOption {
+	return dryRunOption(true)
+}
@@ -0,0 +1,480 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/cavlabs/jiguang-sdk-go/api"
+	"github.com/cavlabs/jiguang-sdk-go/api/jpush/schedule"
+)
+
+const maxNameLength = 255 // 任务名称的最大长度（字节）
+
+// # 变更动作
+type Action string
+
+const (
+	ActionCreate Action = "create" // 创建期望但不存在的定时任务
+	ActionUpdate Action = "update" // 更新与期望不一致的定时任务
+	ActionDelete Action = "delete" // 删除未声明的受管定时任务（需开启 WithPrune）
+	ActionOrphan Action = "orphan" // 未声明的受管定时任务，未开启 WithPrune 时仅报告，不做处理
+)
+
+// # 单个定时任务的变更
+type Change struct {
+	Action     Action              // 变更动作
+	Key        string              // 匹配键
+	ScheduleID string              // 实际定时任务 ID，创建时为空
+	Desired    *schedule.SendParam // 期望的定时任务，删除时为 nil
+	Actual     *schedule.Schedule  // 实际的定时任务，创建时为 nil
+	Diff       []string            // 更新时不一致的字段路径，如 "trigger.periodical.time"、"push.notification.alert"
+}
+
+func (c Change) String() string {
+	var name string
+	switch {
+	case c.Desired != nil:
+		name = c.Desired.Name
+	case c.Actual != nil:
+		name = c.Actual.Name
+	}
+	s := fmt.Sprintf("%-6s %q", c.Action, name)
+	if c.ScheduleID != "" {
+		s += " (" + c.ScheduleID + ")"
+	}
+	if len(c.Diff) > 0 {
+		s += ": " + strings.Join(c.Diff, ", ")
+	}
+	return s
+}
+
+// # 执行计划
+type Plan struct {
+	Changes   []Change // 变更列表，按期望定时任务的声明顺序排列，删除及未声明的定时任务排在最后
+	Unchanged int      // 与期望一致、无需变更的定时任务数
+}
+
+// 是否有需要执行的变更（创建、更新或删除）。
+func (p *Plan) HasChanges() bool {
+	if p == nil {
+		return false
+	}
+	for _, c := range p.Changes {
+		if c.Action != ActionOrphan {
+			return true
+		}
+	}
+	return false
+}
+
+// 指定动作的变更列表。
+func (p *Plan) Filter(action Action) []Change {
+	if p == nil {
+		return nil
+	}
+	var changes []Change
+	for _, c := range p.Changes {
+		if c.Action == action {
+			changes = append(changes, c)
+		}
+	}
+	return changes
+}
+
+// 执行计划的可读文本，可用于审阅或在 CI 中展示。
+func (p *Plan) String() string {
+	if p == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String())
+		b.WriteByte('\n')
+	}
+	fmt.Fprintf(&b, "Plan: %d to create, %d to update, %d to delete, %d orphaned, %d unchanged.",
+		len(p.Filter(ActionCreate)), len(p.Filter(ActionUpdate)), len(p.Filter(ActionDelete)), len(p.Filter(ActionOrphan)), p.Unchanged)
+	return b.String()
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 单个变更的执行结果
+type Outcome struct {
+	Change     Change // 变更
+	ScheduleID string // 定时任务 ID，创建成功时为新任务的 ID
+	Err        error  // 执行失败的原因，执行成功时为 nil
+}
+
+// # 执行结果
+type Result struct {
+	DryRun   bool      // 是否为试运行
+	Outcomes []Outcome // 各变更的执行结果，不含 ActionOrphan
+}
+
+// 执行失败的变更结果。
+func (rs *Result) Failed() []Outcome {
+	if rs == nil {
+		return nil
+	}
+	var failed []Outcome
+	for _, o := range rs.Outcomes {
+		if o.Err != nil {
+			failed = append(failed, o)
+		}
+	}
+	return failed
+}
+
+// 是否所有变更均执行成功。
+func (rs *Result) IsSuccess() bool {
+	return rs != nil && len(rs.Failed()) == 0
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 定时任务调和器
+//
+// 将版本库中声明的期望定时任务（Schedule as Code）与极光上实际的定时任务按匹配键（默认为任务名称）比对，生成执行计划（创建 / 更新 / 删除），
+// 并通过 ScheduleSend、UpdateSchedule、DeleteSchedule 执行，使实际状态与期望一致。
+//
+// 比对规则：期望定时任务中出现的 enabled、trigger、push 字段须与实际一致，实际中多出的字段（如服务端补充的默认值）不视为差异。
+// 因此在极光控制台中对这些字段的修改（漂移）会在执行计划中体现为更新。
+type Reconciler struct {
+	schedule schedule.APIv3
+	cfg      config
+}
+
+// 创建新的定时任务调和器。
+//   - scheduleAPI：【必填】定时任务 API v3 接口，推送 API v3 接口（push.APIv3）已包含定时任务 API v3 接口，可直接传入；
+//   - opts：【可选】调和器配置选项，使用 WithPrune 时必须同时配置非空的 WithNamePrefix。
+func NewReconciler(scheduleAPI schedule.APIv3, opts ...ConfigOption) (*Reconciler, error) {
+	if scheduleAPI == nil {
+		return nil, api.ErrNilJPushScheduleAPIv3
+	}
+
+	c := config{
+		logger:  api.DefaultJPushLogger,
+		keyFunc: func(name string) string { return name },
+	}
+	for _, opt := range opts {
+		if err := opt.apply(&c); err != nil {
+			return nil, err
+		}
+	}
+	if c.prune && c.namePrefix == "" {
+		return nil, errors.New("prune requires a non-empty name prefix")
+	}
+	return &Reconciler{schedule: scheduleAPI, cfg: c}, nil
+}
+
+// 获取所有有效的受管定时任务（自动翻页）。
+func (r *Reconciler) List(ctx context.Context) ([]schedule.Schedule, error) {
+	var schedules []schedule.Schedule
+	for page := 1; ; page++ {
+		result, err := r.schedule.GetSchedules(ctx, page)
+		if err != nil {
+			return nil, err
+		}
+		if err = api.CheckResponse(result.Response, result.Error); err != nil {
+			return nil, fmt.Errorf("get schedules page %d: %w", page, err)
+		}
+		for _, s := range result.Schedules {
+			if strings.HasPrefix(s.Name, r.cfg.namePrefix) {
+				schedules = append(schedules, s)
+			}
+		}
+		if page >= result.TotalPages || len(result.Schedules) == 0 {
+			return schedules, nil
+		}
+	}
+}
+
+// 比对期望的定时任务与实际的定时任务，生成执行计划。
+func (r *Reconciler) Plan(ctx context.Context, desired []schedule.SendParam) (*Plan, error) {
+	if err := r.validate(desired); err != nil {
+		return nil, err
+	}
+	actual, err := r.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return r.diff(desired, actual)
+}
+
+// 执行计划中的变更；配置了 WithDryRun 时仅打印执行计划。
+//
+// 单个变更执行失败不会中止其余变更，失败的变更可从返回结果的 Failed 中获取。
+func (r *Reconciler) Apply(ctx context.Context, plan *Plan) (*Result, error) {
+	if plan == nil {
+		return nil, errors.New("`plan` cannot be nil")
+	}
+
+	result := &Result{DryRun: r.cfg.dryRun}
+	if r.cfg.dryRun {
+		r.cfg.logger.Infof(ctx, "定时任务调和预演：\n%s", plan)
+	}
+	for _, c := range plan.Changes {
+		if c.Action == ActionOrphan {
+			r.cfg.logger.Warnf(ctx, "定时任务 %s（%s）未在声明中，保持不变", c.Actual.Name, c.ScheduleID)
+			continue
+		}
+		o := Outcome{Change: c, ScheduleID: c.ScheduleID}
+		if !r.cfg.dryRun {
+			o.ScheduleID, o.Err = r.apply(ctx, c)
+			if o.Err != nil {
+				r.cfg.logger.Errorf(ctx, "执行变更 %s 失败：%s", c, o.Err)
+			} else {
+				r.cfg.logger.Infof(ctx, "执行变更 %s 成功", c)
+			}
+		}
+		result.Outcomes = append(result.Outcomes, o)
+	}
+	return result, nil
+}
+
+// 生成执行计划并执行，等价于 Plan 后调用 Apply。
+func (r *Reconciler) Reconcile(ctx context.Context, desired []schedule.SendParam) (*Plan, *Result, error) {
+	plan, err := r.Plan(ctx, desired)
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := r.Apply(ctx, plan)
+	return plan, result, err
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+func (r *Reconciler) apply(ctx context.Context, c Change) (string, error) {
+	switch c.Action {
+	case ActionCreate:
+		param := *c.Desired
+		result, err := r.schedule.ScheduleSend(ctx, &param)
+		if err != nil {
+			return "", err
+		}
+		return result.ScheduleID, api.CheckResponse(result.Response, result.Error)
+	case ActionUpdate:
+		enabled := c.Desired.Enabled
+		result, err := r.schedule.UpdateSchedule(ctx, c.ScheduleID, &schedule.UpdateParam{
+			Name:    c.Desired.Name,
+			Enabled: &enabled,
+			Trigger: c.Desired.Trigger,
+			Push:    c.Desired.Push,
+		})
+		if err != nil {
+			return c.ScheduleID, err
+		}
+		return c.ScheduleID, api.CheckResponse(result.Response, result.Error)
+	case ActionDelete:
+		result, err := r.schedule.DeleteSchedule(ctx, c.ScheduleID)
+		if err != nil {
+			return c.ScheduleID, err
+		}
+		return c.ScheduleID, api.CheckResponse(result.Response, result.Error)
+	}
+	return c.ScheduleID, fmt.Errorf("unknown action %q", c.Action)
+}
+
+func (r *Reconciler) validate(desired []schedule.SendParam) error {
+	keys := make(map[string]string, len(desired))
+	for i, d := range desired {
+		switch {
+		case d.Name == "":
+			return fmt.Errorf("schedule #%d: name cannot be empty", i)
+		case len(d.Name) > maxNameLength:
+			return fmt.Errorf("schedule %q: name exceeds %d bytes", d.Name, maxNameLength)
+		case !strings.HasPrefix(d.Name, r.cfg.namePrefix):
+			return fmt.Errorf("schedule %q: name must start with %q", d.Name, r.cfg.namePrefix)
+		case d.Trigger == nil || (d.Trigger.Single == nil && d.Trigger.Periodical == nil):
+			return fmt.Errorf("schedule %q: trigger cannot be empty", d.Name)
+		case d.Push == nil:
+			return fmt.Errorf("schedule %q: push cannot be nil", d.Name)
+		}
+		key := r.cfg.keyFunc(d.Name)
+		if other, ok := keys[key]; ok {
+			return fmt.Errorf("schedules %q and %q have the same key %q", other, d.Name, key)
+		}
+		keys[key] = d.Name
+	}
+	return nil
+}
+
+func (r *Reconciler) diff(desired []schedule.SendParam, actual []schedule.Schedule) (*Plan, error) {
+	// 同一匹配键存在多个实际定时任务时，取第一个（最早创建的），其余视为未声明
+	byKey := make(map[string]*schedule.Schedule, len(actual))
+	for i := range actual {
+		key := r.cfg.keyFunc(actual[i].Name)
+		if _, ok := byKey[key]; !ok {
+			byKey[key] = &actual[i]
+		}
+	}
+
+	plan := &Plan{}
+	matched := make(map[string]bool, len(desired))
+	for i := range desired {
+		d := &desired[i]
+		key := r.cfg.keyFunc(d.Name)
+		a, ok := byKey[key]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Action: ActionCreate, Key: key, Desired: d})
+			continue
+		}
+		matched[a.ScheduleID] = true
+
+		paths, err := compare(d, a)
+		if err != nil {
+			return nil, fmt.Errorf("schedule %q: %w", d.Name, err)
+		}
+		if len(paths) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{Action: ActionUpdate, Key: key, ScheduleID: a.ScheduleID, Desired: d, Actual: a, Diff: paths})
+	}
+
+	for i := range actual {
+		a := &actual[i]
+		if matched[a.ScheduleID] {
+			continue
+		}
+		action := ActionOrphan
+		if r.cfg.prune {
+			action = ActionDelete
+		}
+		plan.Changes = append(plan.Changes, Change{Action: action, Key: r.cfg.keyFunc(a.Name), ScheduleID: a.ScheduleID, Actual: a})
+	}
+	return plan, nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 比对期望与实际的定时任务，返回不一致的字段路径。
+func compare(desired *schedule.SendParam, actual *schedule.Schedule) ([]string, error) {
+	var paths []string
+	if desired.Name != actual.Name {
+		paths = append(paths, "name")
+	}
+	if desired.Enabled != actual.Enabled {
+		paths = append(paths, "enabled")
+	}
+
+	for _, part := range []struct {
+		path         string
+		want, actual interface{}
+	}{
+		{"trigger", desired.Trigger, actual.Trigger},
+		{"push", desired.Push, actual.Push},
+	} {
+		want, err := normalize(part.want)
+		if err != nil {
+			return nil, err
+		}
+		got, err := normalize(part.actual)
+		if err != nil {
+			return nil, err
+		}
+		diffValue(part.path, want, got, &paths)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// 将值序列化为 JSON 后再解析为通用结构，便于逐字段比对。
+func normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var out interface{}
+	if err = dec.Decode(&out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// 比对期望值与实际值：对象只比对期望中出现的字段，其他值要求完全相等。
+func diffValue(path string, want, got interface{}, paths *[]string) {
+	wm, ok := want.(map[string]interface{})
+	if !ok {
+		if !reflect.DeepEqual(want, got) {
+			*paths = append(*paths, path)
+		}
+		return
+	}
+	gm, ok := got.(map[string]interface{})
+	if !ok {
+		*paths = append(*paths, path)
+		return
+	}
+	for k, w := range wm {
+		diffValue(path+"."+k, w, gm[k], paths)
+	}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # 加载期望的定时任务
+//
+// 从 JSON 读取期望的定时任务列表，支持以下两种格式，单个定时任务的结构与 ScheduleSend 的请求参数一致（cid 字段会被忽略）：
+//   - 数组：[{"name": ..., "enabled": ..., "trigger": ..., "push": ...}, ...]；
+//   - 对象：{"schedules": [...]}。
+//
+// 如使用 YAML 维护，可先将其转换为 JSON（如使用 sigs.k8s.io/yaml 的 YAMLToJSON）后再加载。
+func Load(r io.Reader) ([]schedule.SendParam, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimSpace(data)
+
+	var desired []schedule.SendParam
+	if len(data) > 0 && data[0] == '{' {
+		var doc struct {
+			Schedules []schedule.SendParam `json:"schedules"`
+		}
+		err = json.Unmarshal(data, &doc)
+		desired = doc.Schedules
+	} else {
+		err = json.Unmarshal(data, &desired)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range desired {
+		desired[i].CID = ""
+	}
+	return desired, nil
+}
+
+// 从文件加载期望的定时任务，格式同 Load。
+func LoadFile(path string) ([]schedule.SendParam, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Load(bytes.NewReader(data))
+}
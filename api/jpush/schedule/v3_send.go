@@ -36,7 +36,17 @@ import (
 //
 // [docs.jiguang.cn]: https://docs.jiguang.cn/jpush/server/push/rest_api_push_schedule#%E5%88%9B%E5%BB%BA%E5%AE%9A%E6%97%B6%E4%BB%BB%E5%8A%A1
 func (s *apiv3) ScheduleSend(ctx context.Context, param *SendParam) (*SendResult, error) {
-	return s.CustomScheduleSend(ctx, param)
+	if s == nil {
+		return nil, api.ErrNilJPushScheduleAPIv3
+	}
+
+	if param == nil {
+		return nil, errors.New("`param` cannot be nil")
+	}
+
+	localized := *param
+	localized.Trigger = s.localize(param.Trigger)
+	return s.CustomScheduleSend(ctx, &localized)
 }
 
 // # 自定义定时推送
@@ -58,6 +68,11 @@ func (s *apiv3) CustomScheduleSend(ctx context.Context, param interface{}) (*Sen
 		Auth:   s.auth,
 		Body:   param,
 	}
+	if s.sm2 {
+		if err := req.EncryptWithSM2(s.sm2Keys); err != nil {
+			return nil, err
+		}
+	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
 		return nil, err
@@ -117,3 +132,28 @@ type SendResult struct {
 func (rs *SendResult) IsSuccess() bool {
 	return rs != nil && rs.StatusCode/100 == 2 && rs.Error.IsSuccess()
 }
+
+// 将触发条件中的日期时间换算到 API 使用的时区，返回副本，不修改 `trigger`。
+func (s *apiv3) localize(trigger *Trigger) *Trigger {
+	if trigger == nil {
+		return nil
+	}
+	loc := s.loc
+	if loc == nil {
+		loc = jiguang.APILocation()
+	}
+
+	localized := *trigger
+	if trigger.Single != nil {
+		single := *trigger.Single
+		single.Time = jiguang.NewLocalDateTime(single.Time.Time, loc)
+		localized.Single = &single
+	}
+	if trigger.Periodical != nil {
+		periodical := *trigger.Periodical
+		periodical.StartTime = jiguang.NewLocalDateTime(periodical.StartTime.Time, loc)
+		periodical.EndTime = jiguang.NewLocalDateTime(periodical.EndTime.Time, loc)
+		localized.Periodical = &periodical
+	}
+	return &localized
+}
@@ -65,7 +65,12 @@ func (s *apiv3) ScheduleTemplateSend(ctx context.Context, id string, params []Te
 		Proto:  s.proto,
 		URL:    s.host + "/v3/push/template/schedule",
 		Auth:   s.auth,
-		Body:   &templateSendParam{ID: id, Params: params, ScheduleName: scheduleName, Trigger: trigger},
+		Body:   &templateSendParam{ID: id, Params: params, ScheduleName: scheduleName, Trigger: s.localize(trigger)},
+	}
+	if s.sm2 {
+		if err := req.EncryptWithSM2(s.sm2Keys); err != nil {
+			return nil, err
+		}
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
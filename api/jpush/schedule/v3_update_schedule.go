@@ -47,12 +47,14 @@ func (s *apiv3) UpdateSchedule(ctx context.Context, scheduleID string, param *Up
 		return nil, errors.New("`param` cannot be nil")
 	}
 
+	localized := *param
+	localized.Trigger = s.localize(param.Trigger)
 	req := &api.Request{
 		Method: http.MethodPut,
 		Proto:  s.proto,
 		URL:    s.host + "/v3/schedules/" + scheduleID,
 		Auth:   s.auth,
-		Body:   param,
+		Body:   &localized,
 	}
 	resp, err := s.client.Request(ctx, req)
 	if err != nil {
@@ -0,0 +1,85 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// # API 调用限速器
+//
+// 用于批量调用极光 REST API 时控制调用频率：按 `limit`/`per` 的速率均匀放行调用，
+// 并可通过 Observe 根据响应头中的频率控制信息，在当前时间窗口的剩余可用次数耗尽时暂停调用，直到时间窗口重置。
+//
+// 限速器是并发安全的，可在多个 goroutine 之间共享；同一应用的多个批量工具也可共享同一个限速器（通过各自的 WithRateLimiter 选项），共同遵守应用的频率限制。
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // 相邻两次调用的最小间隔
+	next     time.Time     // 下一次允许调用的时间
+}
+
+// 创建新的 API 调用限速器，每 `per` 时间内最多放行 `limit` 次调用。
+func NewRateLimiter(limit int, per time.Duration) (*RateLimiter, error) {
+	if limit <= 0 {
+		return nil, errors.New("`limit` must be positive")
+	}
+	if per <= 0 {
+		return nil, errors.New("`per` must be positive")
+	}
+	return &RateLimiter{interval: per / time.Duration(limit)}, nil
+}
+
+// 等待直到允许下一次调用，或 ctx 被取消。
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if d := at.Sub(now); d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return nil
+}
+
+// 根据响应头中的频率控制信息调整限速：当前时间窗口的剩余可用次数为 0 时，暂停放行调用直到时间窗口重置。
+func (l *RateLimiter) Observe(rate Rate) {
+	if l == nil || rate.Limit <= 0 || rate.Remaining > 0 || rate.Reset <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if resume := time.Now().Add(time.Duration(rate.Reset) * time.Second); resume.After(l.next) {
+		l.next = resume
+	}
+}
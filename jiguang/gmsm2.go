@@ -16,47 +16,268 @@ package jiguang
 
 import (
 	"crypto/rand"
+	"encoding/asn1"
 	"encoding/base64"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"math/big"
+	"strings"
+	"sync"
 
 	"github.com/cavlabs/jiguang-sdk-go/third_party/gmsm/sm2"
 )
 
-const (
-	sm2B64PubKey  = "BPj6Mj/T444gxPaHc6CDCizMRp4pEl14WI2lvIbdEK2c+5XiSqmQt2TQc8hMMZqfxcDqUNQW95puAfQx1asv3rU="
-	sm2B64PrivKey = "EjRWeJA=" // N/A: 在客户端 SDK 中不会使用私钥，因此不提供私钥，私钥应该由极光服务端保存。
-)
+// 极光推送服务端的 SM2 公钥（Base64 编码的未压缩公钥点）。
+const sm2B64PubKey = "BPj6Mj/T444gxPaHc6CDCizMRp4pEl14WI2lvIbdEK2c+5XiSqmQt2TQc8hMMZqfxcDqUNQW95puAfQx1asv3rU="
 
 var (
-	sm2PubKey  *sm2.PublicKey
-	sm2PrivKey *sm2.PrivateKey
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}   // id-ecPublicKey
+	oidNamedCurveSM2  = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301} // sm2p256v1
 )
 
-func init() {
-	var err error
+// 默认的 SM2 公钥提供者，仅包含极光推送服务端的 SM2 公钥。
+var DefaultSM2KeyProvider SM2KeyProvider
 
-	// 初始化 SM2 公钥
-	sm2PubKey, err = desm2PubKey(sm2B64PubKey)
+var ErrNilSM2KeyProvider = errors.New("SM2 key provider is nil") // 未设置 SM2 公钥提供者
+
+func init() {
+	key, err := NewSM2Key("", []byte(sm2B64PubKey))
 	if err != nil {
 		panic(fmt.Sprintf("failed to initialize SM2 public key: %v", err))
 	}
+	ring, err := NewSM2KeyRing(key)
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize SM2 key ring: %v", err))
+	}
+	DefaultSM2KeyProvider = ring
+}
 
-	// 初始化 SM2 私钥
-	sm2PrivKey, err = desm2PrivKey(sm2B64PrivKey)
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # SM2 公钥
+type SM2Key struct {
+	id  string
+	pub *sm2.PublicKey
+}
+
+// 解析 SM2 公钥，`id` 为公钥标识（可为空，非空时会通过请求头 `X-Encrypt-Key-Id` 告知服务端），`data` 支持以下格式：
+//   - PEM 编码的 SubjectPublicKeyInfo（"-----BEGIN PUBLIC KEY-----"）；
+//   - DER 编码的 SubjectPublicKeyInfo；
+//   - Base64 编码的未压缩公钥点（0x04 || X || Y，如极光控制台提供的公钥）；
+//   - 未压缩公钥点的原始字节（65 字节）。
+func NewSM2Key(id string, data []byte) (*SM2Key, error) {
+	pub, err := ParseSM2PublicKey(data)
 	if err != nil {
-		panic(fmt.Sprintf("failed to initialize SM2 private key: %v", err))
+		return nil, err
 	}
+	return &SM2Key{id: id, pub: pub}, nil
 }
 
-// 从 Base64 编码解析 SM2 公钥。
-func desm2PubKey(b64PubKey string) (*sm2.PublicKey, error) {
-	pubKeyBytes, err := base64.StdEncoding.DecodeString(b64PubKey)
+// 公钥标识。
+func (k *SM2Key) ID() string {
+	return k.id
+}
+
+// 公钥。
+func (k *SM2Key) PublicKey() *sm2.PublicKey {
+	return k.pub
+}
+
+// 使用 SM2 公钥加密数据（C1C2C3 模式）并返回 Base64 编码字符串。
+func (k *SM2Key) Encrypt(data []byte) (string, error) {
+	if k == nil || k.pub == nil {
+		return "", errors.New("SM2 public key not initialized")
+	}
+
+	cipherBytes, err := sm2.Encrypt(k.pub, data, rand.Reader, sm2.C1C2C3)
+	if err != nil {
+		return "", fmt.Errorf("SM2 encryption error: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(cipherBytes), nil
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// # SM2 公钥提供者
+//
+// 为 SM2 加密推送提供当前使用的公钥，实现需要保证并发安全。
+type SM2KeyProvider interface {
+	// 获取当前用于加密的 SM2 公钥。
+	CurrentSM2Key() (*SM2Key, error)
+}
+
+// # SM2 公钥环
+//
+// 持有多个同时有效的 SM2 公钥，其中一个为主公钥，用于加密。公钥轮换时，可先通过 Add 添加新公钥，待服务端就绪后通过 SetPrimary
+// 切换主公钥，最后通过 Remove 移除旧公钥，整个过程无需重建 API 客户端。
+type SM2KeyRing struct {
+	mu      sync.RWMutex
+	keys    []*SM2Key
+	primary int
+}
+
+// 创建新的 SM2 公钥环，第一个公钥为主公钥。
+func NewSM2KeyRing(keys ...*SM2Key) (*SM2KeyRing, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("`keys` cannot be empty")
+	}
+	r := &SM2KeyRing{}
+	for _, k := range keys {
+		if err := r.Add(k); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// 添加 SM2 公钥，公钥标识不能与已有公钥重复。
+func (r *SM2KeyRing) Add(key *SM2Key) error {
+	if key == nil {
+		return errors.New("`key` cannot be nil")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.indexOf(key.id) >= 0 {
+		return fmt.Errorf("SM2 key %q already exists", key.id)
+	}
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+// 将指定标识的 SM2 公钥设置为主公钥。
+func (r *SM2KeyRing) SetPrimary(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.indexOf(id)
+	if i < 0 {
+		return fmt.Errorf("SM2 key %q not found", id)
+	}
+	r.primary = i
+	return nil
+}
+
+// 移除指定标识的 SM2 公钥，不能移除主公钥。
+func (r *SM2KeyRing) Remove(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.indexOf(id)
+	if i < 0 {
+		return fmt.Errorf("SM2 key %q not found", id)
+	}
+	if i == r.primary {
+		return fmt.Errorf("cannot remove primary SM2 key %q", id)
+	}
+	r.keys = append(r.keys[:i], r.keys[i+1:]...)
+	if i < r.primary {
+		r.primary--
+	}
+	return nil
+}
+
+// 获取所有 SM2 公钥，主公钥排在第一位；没有公钥时返回 nil。
+func (r *SM2KeyRing) Keys() []*SM2Key {
+	if r == nil {
+		return nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.keys) == 0 {
+		return nil
+	}
+	keys := make([]*SM2Key, 0, len(r.keys))
+	keys = append(keys, r.keys[r.primary])
+	for i, k := range r.keys {
+		if i != r.primary {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func (r *SM2KeyRing) CurrentSM2Key() (*SM2Key, error) {
+	if r == nil {
+		return nil, ErrNilSM2KeyProvider
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if len(r.keys) == 0 {
+		return nil, errors.New("SM2 key ring is empty")
+	}
+	return r.keys[r.primary], nil
+}
+
+func (r *SM2KeyRing) indexOf(id string) int {
+	for i, k := range r.keys {
+		if k.id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 解析 SM2 公钥，支持的格式详见 NewSM2Key。
+func ParseSM2PublicKey(data []byte) (*sm2.PublicKey, error) {
+	if len(data) == 0 {
+		return nil, errors.New("SM2 public key cannot be empty")
+	}
+
+	// PEM
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "PUBLIC KEY" {
+			return nil, fmt.Errorf("invalid SM2 public key PEM block type: %q", block.Type)
+		}
+		return parseSM2PublicKeyDER(block.Bytes)
+	}
+
+	// DER
+	if data[0] == 0x30 { // ASN.1 SEQUENCE
+		return parseSM2PublicKeyDER(data)
+	}
+
+	// 原始公钥点
+	if data[0] == 4 && len(data) == 65 {
+		return parseSM2PublicKeyPoint(data)
+	}
+
+	// Base64 编码的公钥点或 DER
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode Base64 SM2 public key: %w", err)
 	}
+	if len(raw) > 0 && raw[0] == 0x30 {
+		return parseSM2PublicKeyDER(raw)
+	}
+	return parseSM2PublicKeyPoint(raw)
+}
 
+// 从 DER 编码的 SubjectPublicKeyInfo 解析 SM2 公钥。
+func parseSM2PublicKeyDER(der []byte) (*sm2.PublicKey, error) {
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.ObjectIdentifier
+		}
+		PublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(der, &spki)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse DER SM2 public key: %w", err)
+	}
+	if len(rest) > 0 {
+		return nil, errors.New("invalid DER SM2 public key: trailing data")
+	}
+	if !spki.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !spki.Algorithm.Parameters.Equal(oidNamedCurveSM2) {
+		return nil, fmt.Errorf("invalid DER SM2 public key: unsupported algorithm %v (%v)", spki.Algorithm.Algorithm, spki.Algorithm.Parameters)
+	}
+	return parseSM2PublicKeyPoint(spki.PublicKey.RightAlign())
+}
+
+// 从未压缩公钥点解析 SM2 公钥。
+func parseSM2PublicKeyPoint(pubKeyBytes []byte) (*sm2.PublicKey, error) {
 	// Note: `elliptic.Unmarshal` has been deprecated since Go 1.21.
 
 	// 使用 sm2p256v1 曲线
@@ -93,52 +314,16 @@ func desm2PubKey(b64PubKey string) (*sm2.PublicKey, error) {
 	}, nil
 }
 
-// 从 Base64 编码解析 SM2 私钥。
-func desm2PrivKey(b64PrivKey string) (*sm2.PrivateKey, error) {
-	privKeyBytes, err := base64.StdEncoding.DecodeString(b64PrivKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode Base64 SM2 private key: %w", err)
-	}
-
-	// 使用 sm2p256v1 曲线
-	curve := sm2.P256Sm2()
-	privKey := new(sm2.PrivateKey)
-	privKey.D = new(big.Int).SetBytes(privKeyBytes)           // 将字节数组转换为大整数
-	privKey.Curve = curve                                     // 设置公钥曲线
-	privKey.X, privKey.Y = curve.ScalarBaseMult(privKeyBytes) // 计算公钥坐标
-
-	return privKey, nil
-}
+// ---------------------------------------------------------------------------------------------------------------------
 
-// 使用 SM2 公钥加密数据并返回 Base64 编码字符串。
+// 使用默认的 SM2 公钥（DefaultSM2KeyProvider）加密数据并返回 Base64 编码字符串，DefaultSM2KeyProvider 为 nil 时返回 ErrNilSM2KeyProvider。
 func EncryptWithSM2(data []byte) (string, error) {
-	if sm2PubKey == nil {
-		return "", errors.New("SM2 public key not initialized")
-	}
-
-	cipherBytes, err := sm2.Encrypt(sm2PubKey, data, rand.Reader, sm2.C1C2C3)
-	if err != nil {
-		return "", fmt.Errorf("SM2 encryption error: %w", err)
-	}
-
-	return base64.StdEncoding.EncodeToString(cipherBytes), nil
-}
-
-// 使用 SM2 私钥解密 Base64 编码的加密数据。
-func DecryptWithSM2(data string) ([]byte, error) {
-	if sm2PrivKey == nil {
-		return nil, errors.New("SM2 private key not initialized")
-	}
-
-	cipherBytes, err := base64.StdEncoding.DecodeString(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decode data: %w", err)
+	if DefaultSM2KeyProvider == nil {
+		return "", ErrNilSM2KeyProvider
 	}
-
-	plainBytes, err := sm2.Decrypt(sm2PrivKey, cipherBytes, sm2.C1C2C3)
+	key, err := DefaultSM2KeyProvider.CurrentSM2Key()
 	if err != nil {
-		return nil, fmt.Errorf("SM2 decryption error: %w", err)
+		return "", err
 	}
-
-	return plainBytes, nil
+	return key.Encrypt(data)
 }
@@ -15,8 +15,11 @@
 package jiguang_test
 
 import (
+	"encoding/asn1"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/pem"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
@@ -123,7 +126,12 @@ func TestGmSm2(t *testing.T) {
 
 	const plainText = "ABCDEFabdef123456!@#$😄emoji表情😂にちほん"
 	t.Log("\n==== 加密 ====")
-	cipherB64, err := jiguang.EncryptWithSM2([]byte(plainText))
+	key, err := jiguang.NewSM2Key("test", []byte(sm2B64PubKey))
+	if err != nil {
+		t.Errorf("解析公钥失败: %v\n", err)
+		return
+	}
+	cipherB64, err := key.Encrypt([]byte(plainText))
 	if err != nil {
 		t.Errorf("加密失败: %v\n", err)
 		return
@@ -142,10 +150,111 @@ func TestGmSm2(t *testing.T) {
 	// cipherB64 = "BG16SQPntGtstHFJNHERgkuF5eB/scGQc1XyEZ5XpeL7K2EYXNKKPAzYqb5g39wacEdM5Hbpdb5MqSUVKv/ZGp6G8/Ya6q2FRXeJ4zq4osak9XmAiw8uYc1c3K3ShVnDBXYO4B9yMVV8C5or+odL3kt0AfRsyWSLR6ByxODcP5nl9re5GdmllyIqc5CDV8xCU7mUDmUFuI0T7d4jON8Q4w1RFhQd6K67a9Rwza//l2782tZ2oOgO0uBbknnbEvd8rK2OBIr/Z3ZXmcHp9CW18kkwjnvqtipy0g2y/teJ62wmiHPXupUVOld17hjXUU6FQdIfvvzkQeejFbxABBibZhsQpgXHxQimQJ1Nirk++qWqbS4RRmkq8YunxJ5fP8asJ7TnIGWuoij0J/HfuCwwrH++X+ZtL5pAZXJGRIbwq+G7mZuOYW+auRJVAhZ+T7yVrFNf1VqiVL6QLBgp3sUSsCW2hQU9On5z369WSSF0CZCBoJ3AcSFRsLirMf3/N1VyxFB1J8hLM6gvaPbvS+NauFsaaugmtRqwsQufpFacHH+V7bLoryFpdsZGlr8bDoORO94wPIGSwXisVCr++q/TAc7Wxz5DzeN0C/ldo4e4+MTvOBKCx8qoCBGe/CTVZoVpTUP+aFEXd3Vq927NpLCWUrykt26zeOveSuMSAV3cbY5PaEfd0EQLVLDJGsfDeTABGpggOyIWhL8zMijQbSmM0IYh+yM1yDEva2Ecl4FQA3JWKe9vkWCPDqgJbI+Ckjrh1pWn5f+ZnfQRSNKUWXkrS+J4xaRxQTtmUilHNsKckmi07CPNcCyL3Wa3pQQK/BWQsA=="
 
 	t.Log("\n==== 解密 ====")
-	plainBytes, err := jiguang.DecryptWithSM2(cipherB64)
+	plainBytes, err := sm2.Decrypt(privKey, cipherBytes, sm2.C1C2C3)
 	if err != nil {
 		t.Errorf("解密失败: %v\n", err)
 		return
 	}
 	t.Logf("解密结果: %s\n", plainBytes)
+	if string(plainBytes) != plainText {
+		t.Errorf("解密结果与明文不一致: %s", plainBytes)
+	}
+}
+
+func TestSM2KeyFormats(t *testing.T) {
+	point, _ := base64.StdEncoding.DecodeString(sm2B64PubKey)
+
+	type algorithm struct {
+		Algorithm  asn1.ObjectIdentifier
+		Parameters asn1.ObjectIdentifier
+	}
+	der, _ := asn1.Marshal(struct {
+		Algorithm algorithm
+		PublicKey asn1.BitString
+	}{
+		Algorithm: algorithm{
+			Algorithm:  asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1},
+			Parameters: asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301},
+		},
+		PublicKey: asn1.BitString{Bytes: point, BitLength: len(point) * 8},
+	})
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+
+	formats := map[string][]byte{
+		"base64": []byte(sm2B64PubKey),
+		"raw":    point,
+		"der":    der,
+		"pem":    pemBytes,
+	}
+	for name, data := range formats {
+		pub, err := jiguang.ParseSM2PublicKey(data)
+		if err != nil {
+			t.Errorf("%s: 解析公钥失败: %v", name, err)
+			continue
+		}
+		if got := sprintB64PubKey(pub); got != sm2B64PubKey {
+			t.Errorf("%s: 公钥不一致: %s", name, got)
+		}
+	}
+}
+
+func TestSM2KeyRing(t *testing.T) {
+	oldKey, _ := jiguang.NewSM2Key("old", []byte(sm2B64PubKey))
+	newKey, _ := jiguang.NewSM2Key("new", []byte(sm2B64PubKey))
+
+	ring, err := jiguang.NewSM2KeyRing(oldKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = ring.Add(newKey); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := ring.CurrentSM2Key(); key.ID() != "old" {
+		t.Errorf("当前公钥应为 old，实际为 %s", key.ID())
+	}
+	if err = ring.SetPrimary("new"); err != nil {
+		t.Fatal(err)
+	}
+	if err = ring.Remove("new"); err == nil {
+		t.Error("不应允许移除主公钥")
+	}
+	if err = ring.Remove("old"); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := ring.CurrentSM2Key(); key.ID() != "new" {
+		t.Errorf("当前公钥应为 new，实际为 %s", key.ID())
+	}
+	if keys := ring.Keys(); len(keys) != 1 || keys[0].ID() != "new" {
+		t.Errorf("公钥列表应只包含 new，实际为 %v", keys)
+	}
+}
+
+func TestEmptySM2KeyRing(t *testing.T) {
+	var ring jiguang.SM2KeyRing
+	if keys := ring.Keys(); keys != nil {
+		t.Errorf("空公钥环的公钥列表应为 nil，实际为 %v", keys)
+	}
+	if _, err := ring.CurrentSM2Key(); err == nil {
+		t.Error("空公钥环获取当前公钥应返回错误")
+	}
+
+	var nilRing *jiguang.SM2KeyRing
+	if keys := nilRing.Keys(); keys != nil {
+		t.Errorf("nil 公钥环的公钥列表应为 nil，实际为 %v", keys)
+	}
+}
+
+func TestEncryptWithNilSM2KeyProvider(t *testing.T) {
+	saved := jiguang.DefaultSM2KeyProvider
+	defer func() { jiguang.DefaultSM2KeyProvider = saved }()
+
+	jiguang.DefaultSM2KeyProvider = nil
+	if _, err := jiguang.EncryptWithSM2([]byte("hello")); !errors.Is(err, jiguang.ErrNilSM2KeyProvider) {
+		t.Errorf("未设置公钥提供者时应返回 ErrNilSM2KeyProvider，实际为 %v", err)
+	}
+
+	var ring *jiguang.SM2KeyRing
+	if _, err := ring.CurrentSM2Key(); !errors.Is(err, jiguang.ErrNilSM2KeyProvider) {
+		t.Errorf("nil 公钥环应返回 ErrNilSM2KeyProvider，实际为 %v", err)
+	}
 }
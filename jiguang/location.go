@@ -0,0 +1,70 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguang
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// 极光 API 默认使用的时区（北京时间）。
+const DefaultAPILocationName = "Asia/Shanghai"
+
+var apiLocation atomic.Value // *time.Location
+
+func init() {
+	apiLocation.Store(defaultAPILocation())
+}
+
+// 加载默认的 API 时区，运行环境缺少时区数据库时回退为固定的 UTC+8。
+func defaultAPILocation() *time.Location {
+	loc, err := time.LoadLocation(DefaultAPILocationName)
+	if err != nil {
+		return time.FixedZone("CST", 8*60*60)
+	}
+	return loc
+}
+
+// 获取极光 API 使用的时区，默认为 Asia/Shanghai。
+//
+// LocalDate、LocalTime、LocalDateTime、UnitTime 以及字符串形式的 Timestamp 在解析时按该时区解释，
+// LocalDateNow、BuildLocalDate 等函数以及未指定目标时区的 NewLocalDate 等函数也使用该时区；
+// 序列化时直接输出值所携带的墙上时间，不再换算，如需按 API 客户端换算，可使用各构建器的 SetAPILocation。
+func APILocation() *time.Location {
+	return apiLocation.Load().(*time.Location)
+}
+
+// 设置极光 API 使用的时区，`loc` 为 nil 时恢复为默认的 Asia/Shanghai。
+//
+// 注意：这是包级别的全局设置，一般只需在程序启动时设置一次。
+func SetAPILocation(loc *time.Location) {
+	if loc == nil {
+		loc = defaultAPILocation()
+	}
+	apiLocation.Store(loc)
+}
+
+// 将 `t` 换算到目标时区 `loc`（为 nil 时使用 API 时区）。
+func inLocation(t time.Time, loc *time.Location) time.Time {
+	if loc == nil {
+		loc = APILocation()
+	}
+	return t.In(loc)
+}
+
+// 以 `t` 的墙上时间（年月日时分秒）在 `loc` 时区中重建，不做时刻换算。
+func wallIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
@@ -29,6 +29,9 @@ type localTime struct {
 	time.Time
 }
 
+// LocalDate、LocalTime、LocalDateTime 携带完整的 time.Time（含时区），解析时按 API 时区（见 APILocation）解释，序列化时直接输出其墙上时间：
+//   - LocalDate、LocalTime 表示墙上日期与时间，只使用其年月日（时分秒），任何情况下都不做时刻换算；
+//   - LocalDateTime 表示时刻，所携带的时区即其目标时区，可通过 NewLocalDateTime 从任意 time.Time 换算得到。
 type (
 	LocalDate     localTime
 	LocalTime     localTime
@@ -51,35 +54,57 @@ var (
 // ---------------------------------------------------------------------------------------------------------------------
 
 func LocalDateNow() LocalDate {
-	return LocalDate{time.Now()}
+	return LocalDate{time.Now().In(APILocation())}
 }
 
 func LocalTimeNow() LocalTime {
-	return LocalTime{time.Now()}
+	return LocalTime{time.Now().In(APILocation())}
 }
 
 func LocalDateTimeNow() LocalDateTime {
-	return LocalDateTime{time.Now()}
+	return LocalDateTime{time.Now().In(APILocation())}
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
 
+// 从 `t` 创建 LocalDate，取 `t` 在目标时区 `loc`（为 nil 时使用 API 时区）中的日期。
+func NewLocalDate(t time.Time, loc *time.Location) LocalDate {
+	t = inLocation(t, loc)
+	return LocalDate{time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())}
+}
+
+// 从 `t` 创建 LocalTime，取 `t` 在目标时区 `loc`（为 nil 时使用 API 时区）中的时分秒。
+func NewLocalTime(t time.Time, loc *time.Location) LocalTime {
+	t = inLocation(t, loc)
+	return LocalTime{time.Date(zeroStdTime.Year(), zeroStdTime.Month(), zeroStdTime.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location())}
+}
+
+// 从 `t` 创建 LocalDateTime，换算到目标时区 `loc`（为 nil 时使用 API 时区），序列化时输出其在 `loc` 中的墙上时间。
+func NewLocalDateTime(t time.Time, loc *time.Location) LocalDateTime {
+	return LocalDateTime{inLocation(t, loc)}
+}
+
+// ---------------------------------------------------------------------------------------------------------------------
+
+// 按 API 时区的墙上时间构建 LocalDate。
 func BuildLocalDate(year, month, day int) LocalDate {
-	return LocalDate{time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.Local)}
+	return LocalDate{time.Date(year, time.Month(month), day, 0, 0, 0, 0, APILocation())}
 }
 
+// 按 API 时区的墙上时间构建 LocalTime。
 func BuildLocalTime(hour, min, sec int) LocalTime {
-	return LocalTime{time.Date(zeroStdTime.Year(), zeroStdTime.Month(), zeroStdTime.Day(), hour, min, sec, 0, time.Local)}
+	return LocalTime{time.Date(zeroStdTime.Year(), zeroStdTime.Month(), zeroStdTime.Day(), hour, min, sec, 0, APILocation())}
 }
 
+// 按 API 时区的墙上时间构建 LocalDateTime。
 func BuildLocalDateTime(year, month, day, hour, min, sec int) LocalDateTime {
-	return LocalDateTime{time.Date(year, time.Month(month), day, hour, min, sec, 0, time.Local)}
+	return LocalDateTime{time.Date(year, time.Month(month), day, hour, min, sec, 0, APILocation())}
 }
 
 // ---------------------------------------------------------------------------------------------------------------------
 
 func ParseLocalDate(ds string) (LocalDate, error) {
-	st, err := time.Parse(localDateFormat, ds)
+	st, err := time.ParseInLocation(localDateFormat, ds, APILocation())
 	if err != nil {
 		return zeroLocalDate, err
 	}
@@ -87,7 +112,7 @@ func ParseLocalDate(ds string) (LocalDate, error) {
 }
 
 func ParseLocalTime(ts string) (LocalTime, error) {
-	st, err := time.Parse(localTimeFormat, ts)
+	st, err := time.ParseInLocation(localTimeFormat, ts, APILocation())
 	if err != nil {
 		return zeroLocalTime, err
 	}
@@ -95,7 +120,7 @@ func ParseLocalTime(ts string) (LocalTime, error) {
 }
 
 func ParseLocalDateTime(dts string) (LocalDateTime, error) {
-	st, err := time.Parse(localDateTimeFormat, dts)
+	st, err := time.ParseInLocation(localDateTimeFormat, dts, APILocation())
 	if err != nil {
 		return zeroLocalDateTime, err
 	}
@@ -110,7 +135,7 @@ func (t *LocalDate) UnmarshalJSON(data []byte) error {
 		*t = zeroLocalDate
 		return nil
 	}
-	st, err := time.Parse(`"`+localDateFormat+`"`, v)
+	st, err := time.ParseInLocation(`"`+localDateFormat+`"`, v, APILocation())
 	if err != nil {
 		return err
 	}
@@ -137,6 +162,11 @@ func (t LocalDate) String() string {
 	return t.Time.Format(localDateFormat)
 }
 
+// 以其墙上日期在 API 时区中重建的时间。
+func (t LocalDate) InAPILocation() time.Time {
+	return wallIn(t.Time, APILocation())
+}
+
 // ---------------------------------------------------------------------------------------------------------------------
 
 func (t *LocalTime) UnmarshalJSON(data []byte) error {
@@ -145,7 +175,7 @@ func (t *LocalTime) UnmarshalJSON(data []byte) error {
 		*t = zeroLocalTime
 		return nil
 	}
-	st, err := time.Parse(`"`+localTimeFormat+`"`, v)
+	st, err := time.ParseInLocation(`"`+localTimeFormat+`"`, v, APILocation())
 	if err != nil {
 		return err
 	}
@@ -170,6 +200,11 @@ func (t LocalTime) String() string {
 	return t.Time.Format(localTimeFormat)
 }
 
+// 以其墙上时间在 API 时区中重建的时间。
+func (t LocalTime) InAPILocation() time.Time {
+	return wallIn(t.Time, APILocation())
+}
+
 // ---------------------------------------------------------------------------------------------------------------------
 
 func (t *LocalDateTime) UnmarshalJSON(data []byte) error {
@@ -178,7 +213,7 @@ func (t *LocalDateTime) UnmarshalJSON(data []byte) error {
 		*t = zeroLocalDateTime
 		return nil
 	}
-	st, err := time.Parse(`"`+localDateTimeFormat+`"`, v)
+	st, err := time.ParseInLocation(`"`+localDateTimeFormat+`"`, v, APILocation())
 	if err != nil {
 		return err
 	}
@@ -200,3 +235,8 @@ func (t LocalDateTime) Format() string {
 func (t LocalDateTime) String() string {
 	return t.Time.Format(localDateTimeFormat)
 }
+
+// 换算到 API 时区的时刻。
+func (t LocalDateTime) InAPILocation() time.Time {
+	return t.In(APILocation())
+}
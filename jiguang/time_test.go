@@ -0,0 +1,178 @@
+// Copyright 2025 cavlabs/jiguang-sdk-go authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jiguang_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+	_ "time/tzdata" // 保证测试环境中可以加载时区数据
+
+	"github.com/cavlabs/jiguang-sdk-go/jiguang"
+)
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatalf("load location %q: %v", name, err)
+	}
+	return loc
+}
+
+func mustMarshal(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("marshal %v: %v", v, err)
+	}
+	return string(data)
+}
+
+func TestLocalDateZones(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")         // API 时区以东
+	newYork := mustLoadLocation(t, "America/New_York") // API 时区以西
+
+	tests := []struct {
+		name string
+		date jiguang.LocalDate
+		want string
+	}{
+		{"east, target east", jiguang.NewLocalDate(time.Date(2025, 1, 2, 0, 0, 0, 0, tokyo), tokyo), `"2025-01-02"`},
+		{"east, target api", jiguang.NewLocalDate(time.Date(2025, 1, 2, 0, 0, 0, 0, tokyo), nil), `"2025-01-01"`},
+		{"west, target west", jiguang.NewLocalDate(time.Date(2025, 1, 1, 20, 0, 0, 0, newYork), newYork), `"2025-01-01"`},
+		{"west, target api", jiguang.NewLocalDate(time.Date(2025, 1, 1, 20, 0, 0, 0, newYork), nil), `"2025-01-02"`},
+		{"east, wall clock", jiguang.LocalDate{Time: time.Date(2025, 1, 2, 0, 0, 0, 0, tokyo)}, `"2025-01-02"`},
+		{"west, wall clock", jiguang.LocalDate{Time: time.Date(2025, 1, 1, 23, 0, 0, 0, newYork)}, `"2025-01-01"`},
+		{"build", jiguang.BuildLocalDate(2025, 1, 2), `"2025-01-02"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustMarshal(t, tt.date); got != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+			if got := `"` + tt.date.Format() + `"`; got != tt.want {
+				t.Errorf("Format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLocalDateTimeZones(t *testing.T) {
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	newYork := mustLoadLocation(t, "America/New_York")
+	instant := time.Date(2025, 1, 1, 16, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		dateTime jiguang.LocalDateTime
+		want     string
+	}{
+		{"target api", jiguang.NewLocalDateTime(instant, nil), `"2025-01-02 00:30:00"`},
+		{"target east", jiguang.NewLocalDateTime(instant, tokyo), `"2025-01-02 01:30:00"`},
+		{"target west", jiguang.NewLocalDateTime(instant, newYork), `"2025-01-01 11:30:00"`},
+		{"target utc", jiguang.NewLocalDateTime(instant, time.UTC), `"2025-01-01 16:30:00"`},
+		{"build", jiguang.BuildLocalDateTime(2025, 1, 2, 0, 30, 0), `"2025-01-02 00:30:00"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mustMarshal(t, tt.dateTime); got != tt.want {
+				t.Errorf("MarshalJSON() = %s, want %s", got, tt.want)
+			}
+			if !tt.dateTime.InAPILocation().Equal(tt.dateTime.Time) {
+				t.Errorf("InAPILocation() = %v, want the same instant as %v", tt.dateTime.InAPILocation(), tt.dateTime.Time)
+			}
+		})
+	}
+}
+
+func TestLocalTimeDST(t *testing.T) {
+	newYork := mustLoadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name    string
+		instant time.Time
+		want    string
+	}{
+		{"before dst", time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC), `"01:30:00"`},
+		{"after dst", time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC), `"03:30:00"`},
+		{"before std", time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), `"01:30:00"`},
+		{"after std", time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC), `"01:30:00"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lt := jiguang.NewLocalTime(tt.instant, newYork)
+			if got := mustMarshal(t, lt); got != tt.want {
+				t.Errorf("LocalTime MarshalJSON() = %s, want %s", got, tt.want)
+			}
+			// 墙上时间在 API 时区中重建后保持不变。
+			if got := `"` + lt.InAPILocation().Format("15:04:05") + `"`; got != tt.want {
+				t.Errorf("LocalTime InAPILocation() = %s, want %s", got, tt.want)
+			}
+			dt := jiguang.NewLocalDateTime(tt.instant, newYork)
+			if got := dt.Format()[11:]; `"`+got+`"` != tt.want {
+				t.Errorf("LocalDateTime Format() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAPILocation(t *testing.T) {
+	defer jiguang.SetAPILocation(nil)
+
+	tokyo := mustLoadLocation(t, "Asia/Tokyo")
+	instant := time.Date(2025, 1, 1, 16, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		loc          *time.Location
+		wantLoc      string
+		wantDate     string
+		wantDateTime string
+	}{
+		{"default", nil, jiguang.DefaultAPILocationName, `"2025-01-02"`, `"2025-01-02 00:30:00"`},
+		{"utc", time.UTC, "UTC", `"2025-01-01"`, `"2025-01-01 16:30:00"`},
+		{"east", tokyo, "Asia/Tokyo", `"2025-01-02"`, `"2025-01-02 01:30:00"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jiguang.SetAPILocation(tt.loc)
+			if got := jiguang.APILocation().String(); got != tt.wantLoc {
+				t.Fatalf("APILocation() = %s, want %s", got, tt.wantLoc)
+			}
+			if got := mustMarshal(t, jiguang.NewLocalDate(instant, nil)); got != tt.wantDate {
+				t.Errorf("NewLocalDate() = %s, want %s", got, tt.wantDate)
+			}
+			if got := mustMarshal(t, jiguang.NewLocalDateTime(instant, nil)); got != tt.wantDateTime {
+				t.Errorf("NewLocalDateTime() = %s, want %s", got, tt.wantDateTime)
+			}
+
+			// 解析时按 API 时区解释，再次序列化时保持原样。
+			var dt jiguang.LocalDateTime
+			if err := json.Unmarshal([]byte(tt.wantDateTime), &dt); err != nil {
+				t.Fatalf("UnmarshalJSON(): %v", err)
+			}
+			if dt.Location().String() != tt.wantLoc {
+				t.Errorf("UnmarshalJSON() location = %s, want %s", dt.Location(), tt.wantLoc)
+			}
+			if !dt.Equal(instant) {
+				t.Errorf("UnmarshalJSON() = %v, want %v", dt.Time, instant)
+			}
+			if got := mustMarshal(t, dt); got != tt.wantDateTime {
+				t.Errorf("round trip = %s, want %s", got, tt.wantDateTime)
+			}
+		})
+	}
+}
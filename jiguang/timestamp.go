@@ -28,8 +28,14 @@ func TimestampNow() Timestamp {
 	return Timestamp{time.Now()}
 }
 
+// 按 API 时区的墙上时间构建 Timestamp。
 func BuildTimestamp(year, month, day, hour, min, sec int) Timestamp {
-	return Timestamp{time.Date(year, time.Month(month), day, hour, min, sec, 0, time.Local)}
+	return Timestamp{time.Date(year, time.Month(month), day, hour, min, sec, 0, APILocation())}
+}
+
+// 从 `t` 创建 Timestamp，并转换到目标时区 `loc`（为 nil 时使用 API 时区）。
+func NewTimestamp(t time.Time, loc *time.Location) Timestamp {
+	return Timestamp{inLocation(t, loc)}
 }
 
 func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
@@ -40,9 +46,9 @@ func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 	}
 	ts, err := strconv.ParseInt(v, 10, 64)
 	if err == nil {
-		*t = Timestamp{time.Unix(ts, 0)}
+		*t = Timestamp{time.Unix(ts, 0).In(APILocation())}
 		if t.Year() > 3000 { // 处理时间戳为毫秒的情况
-			*t = Timestamp{time.Unix(0, ts*1e6)}
+			*t = Timestamp{time.Unix(0, ts*1e6).In(APILocation())}
 		}
 	} else {
 		var st time.Time
@@ -51,7 +57,7 @@ func (t *Timestamp) UnmarshalJSON(data []byte) (err error) {
 			*t = Timestamp{st}
 			return
 		}
-		st, err = time.Parse(`"`+localDateTimeFormat+`"`, v)
+		st, err = time.ParseInLocation(`"`+localDateTimeFormat+`"`, v, APILocation())
 		if err == nil {
 			*t = Timestamp{st}
 			return
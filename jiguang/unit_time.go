@@ -64,7 +64,7 @@ func (ut *UnitTime) UnmarshalJSON(data []byte) error {
 	v = strings.Trim(v, `"`)
 	// 尝试从时间字符串中解析出时间单位
 	if tu, ok := tryParseTimeUnit(v); ok {
-		t, err := time.Parse(tu.Layout(), v)
+		t, err := time.ParseInLocation(tu.Layout(), v, APILocation())
 		if err != nil {
 			return nil
 		}